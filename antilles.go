@@ -0,0 +1,36 @@
+package countrycodes
+
+import (
+	"strings"
+)
+
+// antilles_successors maps the islands of the former Netherlands Antilles
+// (AN), keyed by lower-cased island name, to the entry that now covers them:
+//
+//	Curacao                       -> CW
+//	Sint Maarten (Saint Martin)   -> SX
+//	Bonaire, Saba, Sint Eustatius -> BQ
+//	Aruba                         -> AW (split off in 1986, before AN was deleted)
+var antilles_successors = map[string]string{
+	"cura\u00E7ao":    "CW",
+	"curacao":         "CW",
+	"sint maarten":    "SX",
+	"saint martin":    "SX",
+	"bonaire":         "BQ",
+	"saba":            "BQ",
+	"sint eustatius":  "BQ",
+	"saint eustatius": "BQ",
+	"statia":          "BQ",
+	"aruba":           "AW",
+}
+
+// ResolveAntillesSuccessor returns the current entry for an island of the
+// former Netherlands Antilles, matching the island name case-insensitively.
+func ResolveAntillesSuccessor(islandName string) (CountryCode, bool) {
+	a2, ok := antilles_successors[strings.ToLower(strings.TrimSpace(islandName))]
+	if !ok {
+		return CountryCode{}, false
+	}
+
+	return GetByAlpha2(a2)
+}
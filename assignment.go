@@ -0,0 +1,166 @@
+package countrycodes
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var assignment_names = map[Assignment]string{
+	OFFICIALLY_ASSIGNED:      "OFFICIALLY_ASSIGNED",
+	USER_ASSIGNED:            "USER_ASSIGNED",
+	EXCEPTIONALLY_RESERVED:   "EXCEPTIONALLY_RESERVED",
+	TRANSITIONALLY_RESERVED:  "TRANSITIONALLY_RESERVED",
+	INDETERMINATELY_RESERVED: "INDETERMINATELY_RESERVED",
+	NOT_USED:                 "NOT_USED",
+}
+
+// String returns the constant name, e.g. "OFFICIALLY_ASSIGNED", or
+// "Assignment(n)" for values outside the defined constants.
+func (a Assignment) String() string {
+	if name, ok := assignment_names[a]; ok {
+		return name
+	}
+
+	return "Assignment(" + strconv.Itoa(int(a)) + ")"
+}
+
+// ParseAssignment returns the assignment named by s, accepting either the
+// constant name ("OFFICIALLY_ASSIGNED") or the human-readable label
+// ("Officially assigned"), case-insensitively.
+func ParseAssignment(s string) (Assignment, error) {
+	name := strings.ToUpper(strings.TrimSpace(s))
+	name = strings.Replace(name, " ", "_", -1)
+
+	for value, n := range assignment_names {
+		if n == name {
+			return value, nil
+		}
+	}
+
+	return 0, fmt.Errorf("countrycodes: unknown assignment %q", s)
+}
+
+// MarshalJSON encodes the assignment as its constant name.
+func (a Assignment) MarshalJSON() ([]byte, error) {
+	name, ok := assignment_names[a]
+	if !ok {
+		return nil, fmt.Errorf("countrycodes: unknown assignment %d", int(a))
+	}
+
+	return json.Marshal(name)
+}
+
+// UnmarshalJSON decodes an assignment from its constant name or, for
+// compatibility with data written before MarshalJSON existed, from its
+// integer value.
+func (a *Assignment) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		for value, n := range assignment_names {
+			if n == name {
+				*a = value
+				return nil
+			}
+		}
+
+		return fmt.Errorf("countrycodes: unknown assignment %q", name)
+	}
+
+	var value int
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("countrycodes: assignment must be a string or integer, got %s", data)
+	}
+
+	if _, ok := assignment_names[Assignment(value)]; !ok {
+		return fmt.Errorf("countrycodes: unknown assignment %d", value)
+	}
+
+	*a = Assignment(value)
+
+	return nil
+}
+
+// IsOfficiallyAssigned reports whether the entry is OFFICIALLY_ASSIGNED.
+func (c CountryCode) IsOfficiallyAssigned() bool {
+	return c.Assignment == OFFICIALLY_ASSIGNED
+}
+
+// IsReserved reports whether the entry is exceptionally, transitionally or
+// indeterminately reserved.
+func (c CountryCode) IsReserved() bool {
+	switch c.Assignment {
+	case EXCEPTIONALLY_RESERVED, TRANSITIONALLY_RESERVED, INDETERMINATELY_RESERVED:
+		return true
+	}
+
+	return false
+}
+
+// IsUserAssigned reports whether the entry is USER_ASSIGNED.
+func (c CountryCode) IsUserAssigned() bool {
+	return c.Assignment == USER_ASSIGNED
+}
+
+// CountByAssignment returns the number of entries with each assignment. The
+// built-in dataset holds 249 officially assigned, 10 exceptionally
+// reserved, 8 transitionally reserved and 1 user-assigned (XK) entries;
+// assignments with no entries are left out.
+func CountByAssignment() map[Assignment]int {
+	counts := make(map[Assignment]int)
+
+	for _, cc := range by_alpha2 {
+		counts[cc.Assignment]++
+	}
+
+	return counts
+}
+
+// assignment_proto pins the protobuf wire value of each assignment. The
+// numbers are part of the wire format and must never change; new
+// assignments get the next unused number. 0 is left for the proto enum's
+// UNSPECIFIED value:
+//
+//	0 ASSIGNMENT_UNSPECIFIED
+//	1 OFFICIALLY_ASSIGNED
+//	2 USER_ASSIGNED
+//	3 EXCEPTIONALLY_RESERVED
+//	4 TRANSITIONALLY_RESERVED
+//	5 INDETERMINATELY_RESERVED
+//	6 NOT_USED
+var assignment_proto = map[Assignment]int32{
+	OFFICIALLY_ASSIGNED:      1,
+	USER_ASSIGNED:            2,
+	EXCEPTIONALLY_RESERVED:   3,
+	TRANSITIONALLY_RESERVED:  4,
+	INDETERMINATELY_RESERVED: 5,
+	NOT_USED:                 6,
+}
+
+// Proto returns the assignment's pinned protobuf enum value, or 0
+// (UNSPECIFIED) for values outside the defined constants.
+func (a Assignment) Proto() int32 {
+	return assignment_proto[a]
+}
+
+// AssignmentFromProto returns the assignment with the given protobuf enum
+// value. 0 (UNSPECIFIED) and unknown values return an error.
+func AssignmentFromProto(v int32) (Assignment, error) {
+	for a, p := range assignment_proto {
+		if p == v {
+			return a, nil
+		}
+	}
+
+	return 0, fmt.Errorf("countrycodes: unknown assignment proto value %d", v)
+}
+
+// AssignmentOf returns the assignment of the entry with the given alpha-2
+// code, ignoring case, or false if the code is not in the dataset at all,
+// as for an unallocated code like "ZZ".
+func AssignmentOf(a2 string) (Assignment, bool) {
+	code, ok := GetByAlpha2(strings.ToUpper(strings.TrimSpace(a2)))
+
+	return code.Assignment, ok
+}
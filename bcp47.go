@@ -0,0 +1,35 @@
+package countrycodes
+
+import (
+	"sort"
+)
+
+// bcp47_extra_regions are the non-ISO region subtags accepted by
+// BCP47Regions, all defined by Unicode CLDR:
+//
+//	001 World
+//	419 Latin America and the Caribbean
+//	EU  European Union
+//	EZ  Eurozone
+//	QO  Outlying Oceania
+//	UN  United Nations
+//
+// ZZ (Unknown Region) is deliberately excluded.
+var bcp47_extra_regions = []string{"001", "419", "EU", "EZ", "QO", "UN"}
+
+// BCP47Regions returns the region subtags accepted in BCP 47 language tags:
+// every officially assigned alpha-2 code plus bcp47_extra_regions, sorted.
+func BCP47Regions() []string {
+	regions := make([]string, 0, len(by_alpha2)+len(bcp47_extra_regions))
+
+	for _, cc := range by_alpha2 {
+		if cc.Assignment == OFFICIALLY_ASSIGNED {
+			regions = append(regions, cc.Alpha2)
+		}
+	}
+
+	regions = append(regions, bcp47_extra_regions...)
+	sort.Strings(regions)
+
+	return regions
+}
@@ -0,0 +1,40 @@
+package countrycodes
+
+import (
+	"fmt"
+)
+
+// binary_version is the first byte of every MarshalBinary encoding.
+const binary_version = 1
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is a
+// version byte followed by the alpha-2 code, or the version byte alone for
+// the zero CountryCode.
+func (c CountryCode) MarshalBinary() ([]byte, error) {
+	return append([]byte{binary_version}, c.Alpha2...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, rebuilding the full
+// entry from the current dataset. An unknown code returns an error wrapping
+// ErrNotFound.
+func (c *CountryCode) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != binary_version {
+		return fmt.Errorf("countrycodes: unsupported binary encoding %q", data)
+	}
+
+	switch len(data) {
+	case 1:
+		*c = CountryCode{}
+		return nil
+	case 3:
+		code, err := LookupByAlpha2(string(data[1:]))
+		if err != nil {
+			return err
+		}
+
+		*c = code
+		return nil
+	}
+
+	return fmt.Errorf("countrycodes: malformed binary encoding %q", data)
+}
@@ -0,0 +1,92 @@
+package countrycodes
+
+import (
+	"strings"
+	"unicode"
+)
+
+// InputKind is the shape of a country identifier as reported by
+// ClassifyInput.
+type InputKind int
+
+const (
+	// KindUnknown is input that matches none of the other shapes.
+	KindUnknown InputKind = iota
+
+	// KindAlpha2 is exactly two ASCII letters, e.g. "US".
+	KindAlpha2
+
+	// KindAlpha3 is exactly three ASCII letters, e.g. "USA".
+	KindAlpha3
+
+	// KindNumeric is one to three ASCII digits, e.g. "840" or "4".
+	KindNumeric
+
+	// KindName is free text made of letters, spaces and the punctuation
+	// found in country names, e.g. "Korea, Republic of".
+	KindName
+)
+
+// ClassifyInput reports the shape of s, ignoring surrounding whitespace,
+// without checking whether a matching entry exists.
+func ClassifyInput(s string) InputKind {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return KindUnknown
+	}
+
+	if isASCIIDigits(s) {
+		if len(s) <= 3 {
+			return KindNumeric
+		}
+		return KindUnknown
+	}
+
+	if isASCIILetters(s) {
+		switch len(s) {
+		case 2:
+			return KindAlpha2
+		case 3:
+			return KindAlpha3
+		}
+	}
+
+	hasLetter := false
+	for _, r := range s {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case r == ' ' || strings.ContainsRune(",.'()-", r):
+		default:
+			return KindUnknown
+		}
+	}
+
+	if hasLetter {
+		return KindName
+	}
+
+	return KindUnknown
+}
+
+func isASCIIDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+func isASCIILetters(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i] | 0x20
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+
+	return s != ""
+}
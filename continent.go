@@ -0,0 +1,95 @@
+package countrycodes
+
+import (
+	"strings"
+)
+
+// continent_members lists the officially assigned entries on each continent,
+// using the seven-continent model with the Americas split into North and
+// South America (Central America and the Caribbean are North America).
+// Remote territories follow GeoNames: the sub-Antarctic islands (BV, GS, HM,
+// TF) are Antarctica, IO, CX and CC are Asia, and CY is Europe.
+var continent_members = map[string][]string{
+	"Africa": {
+		"AO", "BF", "BI", "BJ", "BW", "CD", "CF", "CG", "CI", "CM", "CV", "DJ",
+		"DZ", "EG", "EH", "ER", "ET", "GA", "GH", "GM", "GN", "GQ", "GW", "KE",
+		"KM", "LR", "LS", "LY", "MA", "MG", "ML", "MR", "MU", "MW", "MZ", "NA",
+		"NE", "NG", "RE", "RW", "SC", "SD", "SH", "SL", "SN", "SO", "SS", "ST",
+		"SZ", "TD", "TG", "TN", "TZ", "UG", "YT", "ZA", "ZM", "ZW",
+	},
+	"Antarctica": {
+		"AQ", "BV", "GS", "HM", "TF",
+	},
+	"Asia": {
+		"AE", "AF", "AM", "AZ", "BD", "BH", "BN", "BT", "CC", "CN", "CX", "GE",
+		"HK", "ID", "IL", "IN", "IO", "IQ", "IR", "JO", "JP", "KG", "KH", "KP",
+		"KR", "KW", "KZ", "LA", "LB", "LK", "MM", "MN", "MO", "MV", "MY", "NP",
+		"OM", "PH", "PK", "PS", "QA", "SA", "SG", "SY", "TH", "TJ", "TL", "TM",
+		"TR", "TW", "UZ", "VN", "YE",
+	},
+	"Europe": {
+		"AD", "AL", "AT", "AX", "BA", "BE", "BG", "BY", "CH", "CY", "CZ", "DE",
+		"DK", "EE", "ES", "FI", "FO", "FR", "GB", "GG", "GI", "GR", "HR", "HU",
+		"IE", "IM", "IS", "IT", "JE", "LI", "LT", "LU", "LV", "MC", "MD", "ME",
+		"MK", "MT", "NL", "NO", "PL", "PT", "RO", "RS", "RU", "SE", "SI", "SJ",
+		"SK", "SM", "UA", "VA",
+	},
+	"North America": {
+		"AG", "AI", "AW", "BB", "BL", "BM", "BQ", "BS", "BZ", "CA", "CR", "CU",
+		"CW", "DM", "DO", "GD", "GL", "GP", "GT", "HN", "HT", "JM", "KN", "KY",
+		"LC", "MF", "MQ", "MS", "MX", "NI", "PA", "PM", "PR", "SV", "SX", "TC",
+		"TT", "US", "VC", "VG", "VI",
+	},
+	"Oceania": {
+		"AS", "AU", "CK", "FJ", "FM", "GU", "KI", "MH", "MP", "NC", "NF", "NR",
+		"NU", "NZ", "PF", "PG", "PN", "PW", "SB", "TK", "TO", "TV", "UM", "VU",
+		"WF", "WS",
+	},
+	"South America": {
+		"AR", "BO", "BR", "CL", "CO", "EC", "FK", "GF", "GY", "PE", "PY", "SR",
+		"UY", "VE",
+	},
+}
+
+// applyContinents sets Continent on every entry in continent_members.
+func applyContinents() {
+	for continent, members := range continent_members {
+		for _, a2 := range members {
+			cc := by_alpha2[a2]
+			cc.Continent = continent
+			by_alpha2[a2] = cc
+		}
+	}
+}
+
+// AllByContinent returns the entries on the given continent, matched
+// case-insensitively, sorted by alpha-2. Continent names are "Africa",
+// "Antarctica", "Asia", "Europe", "North America", "Oceania" and
+// "South America".
+func AllByContinent(continent string) []CountryCode {
+	codes := make([]CountryCode, 0)
+
+	for _, cc := range All() {
+		if cc.Continent != "" && strings.EqualFold(cc.Continent, continent) {
+			codes = append(codes, cc)
+		}
+	}
+
+	return codes
+}
+
+// RegionCounts returns the number of officially assigned entries on each
+// continent, keyed by the names AllByContinent accepts. Reserved and
+// user-assigned codes are never counted; territories are, since they hold
+// officially assigned codes of their own.
+func RegionCounts() map[string]int {
+	counts := make(map[string]int)
+
+	for _, cc := range by_alpha2 {
+		if cc.Assignment == OFFICIALLY_ASSIGNED {
+			counts[cc.Continent]++
+		}
+	}
+
+	return counts
+}
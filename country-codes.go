@@ -1,10 +1,23 @@
+// Package countrycodes provides ISO 3166-1 country codes with lookups by
+// alpha-2, alpha-3, numeric code and name.
+//
+// The dataset is built in init and the indexes over it on first use, and
+// both are only modified by Register and LoadOverrides, so as long as
+// neither is called concurrently with them, all lookup, search and
+// enumeration functions are safe for concurrent use by multiple goroutines
+// without additional locking.
 package countrycodes
 
 import (
 	"github.com/tchap/go-patricia/patricia"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 )
 
+//go:generate go run ./internal/gen
+
 type Assignment int
 
 const (
@@ -58,32 +71,70 @@ const (
 	NOT_USED
 )
 
+// CountryCode is a single ISO 3166-1 entry. Alpha2 is its unique key: use
+// Equal to test identity, since two entries can share a Name, as FI and SF
+// do. The == operator instead compares every field, including Assignment and
+// DialingCode, so two values that are Equal can still differ under ==, for
+// example an entry read before LoadOverrides patched it and the same entry
+// read after. CountryCode is comparable, so list data such as Languages,
+// Borders and Timezones is read through methods rather than held in fields.
 type CountryCode struct {
-	Name        string
-	Alpha2      string
-	Alpha3      string
-	Numeric     int
-	DialingCode string
-	Assignment  Assignment
+	Name            string
+	Alpha2          string
+	Alpha3          string
+	Numeric         int
+	DialingCode     string
+	Assignment      Assignment
+	Independent     bool
+	Continent       string
+	RegionCode      int
+	Region          WorldRegion
+	CurrencyCode    string
+	CurrencyNumeric int
+	CurrencySymbol  string
+	TLD             string
+	DrivesOnLeft    bool
+	Latitude        float64
+	Longitude       float64
 }
 
 var by_alpha2 map[string]CountryCode
 
+var by_alpha2_ptr map[string]*CountryCode
+
+// sorted_alpha2 holds every alpha-2 code in ascending order, for ForEach.
+var sorted_alpha2 []string
+
 var by_name map[string]CountryCode
 
 var by_alpha3 map[string]CountryCode
 
+var by_historical_alpha3 map[string]CountryCode
+
 var by_numeric map[int]CountryCode
 
 var name_trie *patricia.Trie
 
-func init() {
+// name_keys holds the same keys as name_trie in ascending order, so that a
+// prefix's matches are a contiguous range found by binary search.
+var name_keys []nameKey
 
-	by_name = make(map[string]CountryCode)
-	by_alpha3 = make(map[string]CountryCode)
-	by_numeric = make(map[int]CountryCode)
-	name_trie = patricia.NewTrie()
+// nameKey is one entry of name_keys.
+type nameKey struct {
+	key string
+	cc  CountryCode
+}
+
+var code_trie *patricia.Trie
+
+// indexes guards the lazy construction of every index other than by_alpha2,
+// so programs that only look up alpha-2 codes never pay for them.
+var indexes sync.Once
 
+func init() {
+
+	// The by_alpha2 literal is generated from internal/gen/iso3166.csv; edit
+	// the CSV and run "go generate" rather than changing it by hand.
 	by_alpha2 = map[string]CountryCode{
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/Ascension_Island">Ascension Island</a>
@@ -101,7 +152,7 @@ func init() {
 
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/Andorra">Andorra</a>
-		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#AD">AD</a>, AND, 16,
+		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#AD">AD</a>, AND, 20,
 		 * Officially assigned]
 		 */
 		"AD": CountryCode{
@@ -115,7 +166,7 @@ func init() {
 
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/United_Arab_Emirates">United Arab Emirates</a>
-		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#AE">AE</a>, AE, 784,
+		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#AE">AE</a>, ARE, 784,
 		 * Officially assigned]
 		 */
 		"AE": CountryCode{
@@ -200,7 +251,7 @@ func init() {
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/Netherlands_Antilles">Netherlands Antilles</a>
 		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#AN">AN</a>, ANHH, 530,
-		 * Traditionally reserved]
+		 * Transitionally reserved]
 		 */
 		"AN": CountryCode{
 			Name:        "Netherlands Antilles",
@@ -578,7 +629,7 @@ func init() {
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/Burma">Burma</a>
 		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#BU">BU</a>, BUMM, 104,
-		 * Officially assigned]
+		 * Transitionally reserved]
 		 *
 		 * @see #MM
 		 */
@@ -660,6 +711,7 @@ func init() {
 			DialingCode: "+1",
 			Assignment:  OFFICIALLY_ASSIGNED,
 		},
+
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/Cocos_(Keeling)_Islands">Cocos (Keeling) Islands</a>
 		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#CC">CC</a>, CCK, 166,
@@ -799,6 +851,7 @@ func init() {
 			DialingCode: "+86",
 			Assignment:  OFFICIALLY_ASSIGNED,
 		},
+
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/Colombia">Colombia</a>
 		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#CO">CO</a>, COL, 170,
@@ -844,7 +897,7 @@ func init() {
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/Serbia_and_Montenegro">Serbia and Montenegro</a>
 		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#CS">CS</a>, CSXX, 891,
-		 * Traditionally reserved]
+		 * Transitionally reserved]
 		 */
 		"CS": CountryCode{
 			Name:        "Serbia and Montenegro",
@@ -1182,7 +1235,7 @@ func init() {
 
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/Fiji">Fiji</a>
-		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#">FJ</a>, FJI, 242,
+		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#FJ">FJ</a>, FJI, 242,
 		 * Officially assigned]
 		 */
 		"FJ": CountryCode{
@@ -1265,7 +1318,7 @@ func init() {
 		},
 
 		/**
-		 * <a href="http://en.wikipedia.org/wiki/Gabon">Gabon </a>
+		 * <a href="http://en.wikipedia.org/wiki/Gabon">Gabon</a>
 		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#GA">GA</a>, GAB, 266,
 		 * Officially assigned]
 		 */
@@ -1767,6 +1820,7 @@ func init() {
 			DialingCode: "+39",
 			Assignment:  OFFICIALLY_ASSIGNED,
 		},
+
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/Jersey">Jersey</a>
 		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#JE">JE</a>, JEY, 832,
@@ -1822,6 +1876,7 @@ func init() {
 			DialingCode: "+81",
 			Assignment:  OFFICIALLY_ASSIGNED,
 		},
+
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/Kenya">Kenya</a>
 		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#KE">KE</a>, KEN, 404,
@@ -2288,7 +2343,7 @@ func init() {
 
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/Macau">Macao</a>
-		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#MO">MO</a>, MCO, 492,
+		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#MO">MO</a>, MAC, 446,
 		 * Officially assigned]
 		 */
 		"MO": CountryCode{
@@ -2373,7 +2428,7 @@ func init() {
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/Mauritius">Mauritius</a>
 		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#MU">MU</a>, MUS, 480,
-		 * Officially assigned]]
+		 * Officially assigned]
 		 */
 		"MU": CountryCode{
 			Name:        "Mauritius",
@@ -2597,7 +2652,7 @@ func init() {
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/Saudi%E2%80%93Iraqi_neutral_zone">Neutral Zone</a>
 		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#NT">NT</a>, NTHH, 536,
-		 * Traditionally reserved]
+		 * Transitionally reserved]
 		 */
 		"NT": CountryCode{
 			Name:        "Neutral Zone",
@@ -2637,7 +2692,7 @@ func init() {
 		},
 
 		/**
-		 * <a href=http://en.wikipedia.org/wiki/Oman"">Oman</a>
+		 * <a href="http://en.wikipedia.org/wiki/Oman">Oman</a>
 		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#OM">OM</a>, OMN, 512,
 		 * Officially assigned]
 		 */
@@ -3003,7 +3058,7 @@ func init() {
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/Finland">Finland</a>
 		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#SF">SF</a>, FIN, 246,
-		 * Traditionally reserved]
+		 * Transitionally reserved]
 		 *
 		 * @see #FI
 		 */
@@ -3257,7 +3312,7 @@ func init() {
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/Tristan_da_Cunha">Tristan da Cunha</a>
 		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#TA">TA</a>, TAA, -1,
-		 * Exceptionally reserved.
+		 * Exceptionally reserved]
 		 */
 		"TA": CountryCode{
 			Name:        "Tristan da Cunha",
@@ -3320,7 +3375,7 @@ func init() {
 			Alpha2:      "TG",
 			Alpha3:      "TGO",
 			Numeric:     768,
-			DialingCode: "228",
+			DialingCode: "+228",
 			Assignment:  OFFICIALLY_ASSIGNED,
 		},
 
@@ -3425,7 +3480,7 @@ func init() {
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/East_Timor">East Timor</a>
 		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#TP">TP</a>, TPTL, 0,
-		 * Traditionally reserved]
+		 * Transitionally reserved]
 		 *
 		 * <p>
 		 * ISO 3166-1 numeric code is unknown.
@@ -3551,6 +3606,7 @@ func init() {
 			DialingCode: "+44",
 			Assignment:  EXCEPTIONALLY_RESERVED,
 		},
+
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/United_States_Minor_Outlying_Islands">United States Minor Outlying Islands</a>
 		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#UM">UM</a>, UMI, 581,
@@ -3578,6 +3634,7 @@ func init() {
 			DialingCode: "+1",
 			Assignment:  OFFICIALLY_ASSIGNED,
 		},
+
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/Uruguay">Uruguay</a>
 		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#UY">UY</a>, URY, 858,
@@ -3645,8 +3702,7 @@ func init() {
 			Alpha3:      "VEN",
 			Numeric:     862,
 			DialingCode: "+58",
-
-			Assignment: OFFICIALLY_ASSIGNED,
+			Assignment:  OFFICIALLY_ASSIGNED,
 		},
 
 		/**
@@ -3778,7 +3834,7 @@ func init() {
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/Yugoslavia">Yugoslavia</a>
 		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#YU">YU</a>, YUCS, 890,
-		 * Traditionally reserved]
+		 * Transitionally reserved]
 		 */
 		"YU": CountryCode{
 			Name:        "Yugoslavia",
@@ -3820,7 +3876,7 @@ func init() {
 		/**
 		 * <a href="http://en.wikipedia.org/wiki/Zaire">Zaire</a>
 		 * [<a href="http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#ZR">ZR</a>, ZRCD, 0,
-		 * Traditionally reserved]
+		 * Transitionally reserved]
 		 *
 		 * <p>
 		 * ISO 3166-1 numeric code is unknown.
@@ -3850,14 +3906,127 @@ func init() {
 		},
 	}
 
+	applyContinents()
+	applyRegionCodes()
+	applyWorldRegions()
+	applyCurrencies()
+	applyTLDs()
+	applyDrivingSides()
+	applyCentroids()
+
+	for a2, cc := range by_alpha2 {
+		cc.Independent = cc.Assignment == OFFICIALLY_ASSIGNED && !dependent[a2]
+		by_alpha2[a2] = cc
+	}
+}
+
+// ensureIndexes builds the secondary indexes on first use. Every function
+// reading them must call it first.
+func ensureIndexes() {
+	indexes.Do(buildIndexes)
+}
+
+// buildIndexes builds every index other than by_alpha2 from scratch.
+func buildIndexes() {
+	by_alpha2_ptr = make(map[string]*CountryCode)
+	sorted_alpha2 = make([]string, 0, len(by_alpha2))
+	by_name = make(map[string]CountryCode)
+	by_alpha3 = make(map[string]CountryCode)
+	by_historical_alpha3 = make(map[string]CountryCode)
+	by_numeric = make(map[int]CountryCode)
+	by_tld = make(map[string]CountryCode)
+	name_trie = patricia.NewTrie()
+	name_keys = make([]nameKey, 0, 2*len(by_alpha2))
+	code_trie = patricia.NewTrie()
+
 	for _, cc := range by_alpha2 {
-		if cc.Alpha3 != "" {
-			by_alpha3[cc.Alpha3] = cc
-		}
+		index(cc)
+	}
+}
+
+// index adds cc to every index other than by_alpha2.
+func index(cc CountryCode) {
+	shared := cc
+	by_alpha2_ptr[cc.Alpha2] = &shared
+	if i := sort.SearchStrings(sorted_alpha2, cc.Alpha2); i == len(sorted_alpha2) || sorted_alpha2[i] != cc.Alpha2 {
+		sorted_alpha2 = append(sorted_alpha2, "")
+		copy(sorted_alpha2[i+1:], sorted_alpha2[i:])
+		sorted_alpha2[i] = cc.Alpha2
+	}
+
+	switch len(cc.Alpha3) {
+	case 3:
+		by_alpha3[cc.Alpha3] = cc
+	case 4:
+		by_historical_alpha3[cc.Alpha3] = cc
+	}
+	// Reserved codes such as BU, SF and UK keep the numeric code, ccTLD or
+	// name of the entry that replaced them, so on a clash the officially
+	// assigned entry wins whatever order the map yields them in.
+	if other, ok := by_name[cc.Name]; !ok || preferEntry(cc, other) {
 		by_name[cc.Name] = cc
+	}
+	if other, ok := by_numeric[cc.Numeric]; !ok || preferEntry(cc, other) {
 		by_numeric[cc.Numeric] = cc
-		name_trie.Insert(patricia.Prefix(strings.ToLower(cc.Name)), cc)
 	}
+	if other, ok := by_tld[cc.TLD]; cc.TLD != "" && (!ok || preferEntry(cc, other)) {
+		by_tld[cc.TLD] = cc
+	}
+	lower := strings.ToLower(cc.Name)
+	for _, key := range []string{lower, foldDiacritics(lower)} {
+		if other := name_trie.Get(patricia.Prefix(key)); other == nil || preferEntry(cc, other.(CountryCode)) {
+			name_trie.Set(patricia.Prefix(key), cc)
+		}
+
+		i := sort.Search(len(name_keys), func(i int) bool { return name_keys[i].key >= key })
+		if i == len(name_keys) || name_keys[i].key != key {
+			name_keys = append(name_keys, nameKey{})
+			copy(name_keys[i+1:], name_keys[i:])
+			name_keys[i] = nameKey{key, cc}
+		} else if preferEntry(cc, name_keys[i].cc) {
+			name_keys[i].cc = cc
+		}
+	}
+	code_trie.Set(patricia.Prefix(cc.Alpha2), cc)
+}
+
+// preferEntry reports whether cc should replace other in an index where
+// both have the same key: officially assigned entries win, and otherwise the
+// lower alpha-2 code does, so the result does not depend on map order.
+func preferEntry(cc, other CountryCode) bool {
+	if (cc.Assignment == OFFICIALLY_ASSIGNED) != (other.Assignment == OFFICIALLY_ASSIGNED) {
+		return cc.Assignment == OFFICIALLY_ASSIGNED
+	}
+
+	return cc.Alpha2 < other.Alpha2
+}
+
+// Equal reports whether c and other are the same entry, comparing Alpha2.
+func (c CountryCode) Equal(other CountryCode) bool {
+	return c.Alpha2 == other.Alpha2
+}
+
+// IsZero reports whether c is the zero value the getters return when no
+// entry matches.
+func (c CountryCode) IsZero() bool {
+	return c.Alpha2 == ""
+}
+
+// Canonical returns the officially assigned entry sharing c's Name, so both
+// FI and SF yield FI. Entries that are officially assigned themselves, or
+// share their Name with no officially assigned entry, are returned as is.
+func (c CountryCode) Canonical() CountryCode {
+	if c.Assignment == OFFICIALLY_ASSIGNED {
+		return c
+	}
+
+	ensureIndexes()
+
+	if cc, ok := by_name[c.Name]; ok && cc.Assignment == OFFICIALLY_ASSIGNED {
+		return cc
+	}
+
+	return c
 }
 
 func GetByAlpha2(a2 string) (CountryCode, bool) {
@@ -3866,33 +4035,365 @@ func GetByAlpha2(a2 string) (CountryCode, bool) {
 	return code, code.Alpha2 != ""
 }
 
+// GetByAlpha2Ptr is GetByAlpha2 returning a pointer to the shared entry
+// rather than a copy, for hot paths where the copy matters. The entry is
+// never modified, so the pointer is safe to share between goroutines, but
+// callers must not modify it either.
+func GetByAlpha2Ptr(a2 string) (*CountryCode, bool) {
+	ensureIndexes()
+
+	code, ok := by_alpha2_ptr[a2]
+
+	return code, ok
+}
+
+// HasAlpha3 reports whether the entry has a three-letter alpha-3 code, and
+// so can be found with GetByAlpha3. EA, EU, IC and UK have none, and the
+// deleted entries carry four-letter ISO 3166-3 codes instead, which
+// GetByHistoricalAlpha3 finds.
+func (c CountryCode) HasAlpha3() bool {
+	return len(c.Alpha3) == 3
+}
+
+// GetByAlpha3 returns the entry with the given three-letter alpha-3 code,
+// ignoring case and surrounding whitespace, so "deu" and " DEU " both find
+// Germany. The four-letter codes of deleted entries are not matched; use
+// GetByHistoricalAlpha3 for those.
 func GetByAlpha3(a3 string) (CountryCode, bool) {
-	code := by_alpha3[a3]
+	ensureIndexes()
+
+	code, ok := by_alpha3[a3]
+	if !ok {
+		code = by_alpha3[strings.ToUpper(strings.TrimSpace(a3))]
+	}
 
 	return code, code.Alpha2 != ""
 }
 
+// GetByHistoricalAlpha3 returns the entry with the given four-letter code,
+// ignoring case and surrounding whitespace, so "yucs" and " YUCS " both find
+// Yugoslavia. ISO 3166-3 assigns these to countries deleted from ISO 3166-1; the dataset
+// carries ANHH (Netherlands Antilles), BUMM (Burma), CSXX (Serbia and
+// Montenegro), NTHH (Neutral Zone), TPTL (East Timor), YUCS (Yugoslavia)
+// and ZRCD (Zaire).
+func GetByHistoricalAlpha3(a4 string) (CountryCode, bool) {
+	ensureIndexes()
+
+	code, ok := by_historical_alpha3[a4]
+	if !ok {
+		code = by_historical_alpha3[strings.ToUpper(strings.TrimSpace(a4))]
+	}
+
+	return code, code.Alpha2 != ""
+}
+
+// GetByName returns the entry with exactly the given name. A leading "The "
+// or trailing ", The" is ignored if the name does not match as given, so
+// "The Gambia" finds "Gambia".
 func GetByName(name string) (CountryCode, bool) {
-	code := by_name[name]
+	ensureIndexes()
+
+	code, ok := by_name[name]
+	if !ok {
+		code = by_name[stripArticle(name)]
+	}
 
 	return code, code.Alpha2 != ""
 }
 
+// stripArticle removes a leading "The " or trailing ", The" from name,
+// ignoring the article's case.
+func stripArticle(name string) string {
+	name = strings.TrimSpace(name)
+
+	if len(name) > 4 && strings.EqualFold(name[:4], "the ") {
+		return strings.TrimSpace(name[4:])
+	}
+
+	if n := len(name); n > 5 && strings.EqualFold(name[n-5:], ", the") {
+		return strings.TrimSpace(name[:n-5])
+	}
+
+	return name
+}
+
 func GetByNumeric(numeric int) (CountryCode, bool) {
+	ensureIndexes()
+
 	code := by_numeric[numeric]
 
 	return code, code.Alpha2 != ""
 }
 
+// GetByNumericString returns the entry with the numeric code written as up
+// to three decimal digits, zero-padded or not, so "004" and "4" both find
+// Afghanistan. Non-numeric input and the -1 and 0 placeholders match
+// nothing.
+func GetByNumericString(s string) (CountryCode, bool) {
+	s = strings.TrimSpace(s)
+	if ClassifyInput(s) != KindNumeric {
+		return CountryCode{}, false
+	}
+
+	numeric, err := strconv.Atoi(s)
+	if err != nil || !IsValidNumeric(numeric) {
+		return CountryCode{}, false
+	}
+
+	return GetByNumeric(numeric)
+}
+
+// IsCountryNumeric reports whether n is the numeric code of an officially
+// assigned country. UN M49 region aggregates such as 001 (World) or 150
+// (Europe) share the numeric space but are not in the dataset, so they
+// report false, as do the numerics of reserved entries.
+func IsCountryNumeric(n int) bool {
+	for _, cc := range by_alpha2 {
+		if cc.Numeric == n && cc.Assignment == OFFICIALLY_ASSIGNED {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FindByName returns the entries whose name starts with prefix, ignoring case
+// and diacritics, so "reunion" and "cote d" find Réunion and Côte
+// d'Ivoire. An empty or whitespace-only prefix matches nothing; use All for
+// every entry.
 func FindByName(prefix string) (matches []CountryCode) {
+	ensureIndexes()
+
 	matches = make([]CountryCode, 0)
+	if strings.TrimSpace(prefix) == "" {
+		return
+	}
+
+	seen := make(map[string]bool)
 
 	visit := func(prefix patricia.Prefix, item patricia.Item) error {
-		matches = append(matches, item.(CountryCode))
+		cc := item.(CountryCode)
+		if !seen[cc.Alpha2] {
+			seen[cc.Alpha2] = true
+			matches = append(matches, cc)
+		}
 		return nil
 	}
 
-	name_trie.VisitSubtree(patricia.Prefix(strings.ToLower(prefix)), visit)
+	lower := strings.ToLower(prefix)
+	name_trie.VisitSubtree(patricia.Prefix(lower), visit)
+	name_trie.VisitSubtree(patricia.Prefix(foldDiacritics(lower)), visit)
 
 	return
 }
+
+// FindByAlpha2Prefix returns the entries whose alpha-2 code starts with
+// prefix, ignoring case, sorted by alpha-2. An empty or whitespace-only
+// prefix matches nothing.
+func FindByAlpha2Prefix(prefix string) []CountryCode {
+	ensureIndexes()
+
+	matches := make([]CountryCode, 0)
+
+	prefix = strings.ToUpper(strings.TrimSpace(prefix))
+	if prefix == "" {
+		return matches
+	}
+
+	code_trie.VisitSubtree(patricia.Prefix(prefix), func(prefix patricia.Prefix, item patricia.Item) error {
+		matches = append(matches, item.(CountryCode))
+		return nil
+	})
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Alpha2 < matches[j].Alpha2
+	})
+
+	return matches
+}
+
+// FindByNameMap returns the same matches as FindByName, keyed by Alpha2.
+func FindByNameMap(prefix string) map[string]CountryCode {
+	matches := FindByName(prefix)
+
+	byAlpha2 := make(map[string]CountryCode, len(matches))
+	for _, cc := range matches {
+		byAlpha2[cc.Alpha2] = cc
+	}
+
+	return byAlpha2
+}
+
+// FindByNameFiltered returns the matches FindByName would return whose
+// Assignment is one of assignments, or OFFICIALLY_ASSIGNED if none are
+// given, in the same order.
+func FindByNameFiltered(prefix string, assignments ...Assignment) []CountryCode {
+	if len(assignments) == 0 {
+		assignments = []Assignment{OFFICIALLY_ASSIGNED}
+	}
+
+	matches := make([]CountryCode, 0)
+
+	for _, cc := range FindByName(prefix) {
+		for _, a := range assignments {
+			if cc.Assignment == a {
+				matches = append(matches, cc)
+				break
+			}
+		}
+	}
+
+	return matches
+}
+
+// FindByNameSorted returns the same matches as FindByName, sorted by Name.
+func FindByNameSorted(prefix string) []CountryCode {
+	matches := FindByName(prefix)
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Name < matches[j].Name
+	})
+
+	return matches
+}
+
+// FindByNameLimit returns at most n of the matches FindByNameSorted would
+// return, taking the first n by Name so that the result is stable across
+// calls. A negative n means no limit.
+//
+// Rather than walk name_trie it takes the matching range of name_keys by
+// binary search, which BenchmarkFindByNameLimit shows is about twice as fast
+// even for single-letter prefixes, where the range is widest.
+func FindByNameLimit(prefix string, n int) []CountryCode {
+	ensureIndexes()
+
+	matches := make([]CountryCode, 0)
+	if strings.TrimSpace(prefix) == "" {
+		return matches
+	}
+
+	seen := make(map[string]bool)
+	lower := strings.ToLower(prefix)
+
+	for _, p := range []string{lower, foldDiacritics(lower)} {
+		i := sort.Search(len(name_keys), func(i int) bool { return name_keys[i].key >= p })
+		for ; i < len(name_keys) && strings.HasPrefix(name_keys[i].key, p); i++ {
+			if cc := name_keys[i].cc; !seen[cc.Alpha2] {
+				seen[cc.Alpha2] = true
+				matches = append(matches, cc)
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Name < matches[j].Name
+	})
+
+	if n >= 0 && len(matches) > n {
+		matches = matches[:n]
+	}
+
+	return matches
+}
+
+// SearchByName returns the entries whose name contains substr, ignoring case,
+// diacritics and a leading "The " or trailing ", The" on substr, sorted by
+// name. It is a linear scan over the dataset, so each call is O(n) in the
+// number of entries; a blank substr matches nothing.
+func SearchByName(substr string) []CountryCode {
+	matches := make([]CountryCode, 0)
+
+	substr = foldDiacritics(strings.ToLower(stripArticle(substr)))
+	if substr == "" {
+		return matches
+	}
+
+	for _, cc := range All() {
+		if strings.Contains(foldDiacritics(strings.ToLower(cc.Name)), substr) {
+			matches = append(matches, cc)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Name < matches[j].Name
+	})
+
+	return matches
+}
+
+// FindOrSearch returns FindByName's prefix matches for query or, only when
+// there are none, SearchByName's substring matches. The cheap trie lookup
+// answers most queries; the linear scan runs only when it finds nothing, so
+// "islands" still matches the many "... Islands" entries.
+func FindOrSearch(query string) []CountryCode {
+	if matches := FindByName(query); len(matches) > 0 {
+		return matches
+	}
+
+	return SearchByName(query)
+}
+
+// All returns a new slice holding every entry, sorted by alpha-2.
+func All() []CountryCode {
+	codes := make([]CountryCode, 0, len(by_alpha2))
+
+	for _, cc := range by_alpha2 {
+		codes = append(codes, cc)
+	}
+
+	sort.Slice(codes, func(i, j int) bool {
+		return codes[i].Alpha2 < codes[j].Alpha2
+	})
+
+	return codes
+}
+
+// AllExcludingReserved returns the officially and user-assigned entries,
+// sorted by Name, leaving out every exceptionally, transitionally or
+// indeterminately reserved and not used entry. This is the usual list for a
+// country picker.
+func AllExcludingReserved() []CountryCode {
+	codes := make([]CountryCode, 0)
+
+	for _, cc := range All() {
+		if cc.IsOfficiallyAssigned() || cc.IsUserAssigned() {
+			codes = append(codes, cc)
+		}
+	}
+
+	sort.SliceStable(codes, func(i, j int) bool {
+		return codes[i].Name < codes[j].Name
+	})
+
+	return codes
+}
+
+// ForEach calls fn for every entry in ascending alpha-2 order, stopping
+// early if fn returns false. The order is stable across versions. Unlike
+// All, it does not allocate a slice of entries.
+func ForEach(fn func(CountryCode) bool) {
+	ensureIndexes()
+
+	for _, a2 := range sorted_alpha2 {
+		if !fn(by_alpha2[a2]) {
+			return
+		}
+	}
+}
+
+// Count returns the number of entries in the dataset.
+func Count() int {
+	return len(by_alpha2)
+}
+
+// AllSorted returns a new slice of every entry ordered by less. Ties keep
+// alpha-2 order, so the result is stable across runs.
+func AllSorted(less func(a, b CountryCode) bool) []CountryCode {
+	codes := All()
+
+	sort.SliceStable(codes, func(i, j int) bool {
+		return less(codes[i], codes[j])
+	})
+
+	return codes
+}
@@ -1,6 +1,18 @@
 package countrycodes
 
 import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -25,3 +37,2571 @@ func TestGetByNumeric(t *testing.T) {
 		t.Fatalf("GetByNumeric failed")
 	}
 }
+
+func TestGetByNumericPrefersOfficial(t *testing.T) {
+	for numeric, a2 := range map[int]string{104: "MM", 246: "FI"} {
+		if code, _ := GetByNumeric(numeric); code.Alpha2 != a2 {
+			t.Fatalf("GetByNumeric(%d) returned %s, expected %s", numeric, code.Alpha2, a2)
+		}
+	}
+}
+
+func TestDisplayName(t *testing.T) {
+	kr, _ := GetByAlpha2("KR")
+
+	if kr.DisplayName(NameCommon) != "South Korea" {
+		t.Fatalf("DisplayName(NameCommon) for KR failed")
+	}
+
+	if kr.DisplayName(NameOfficial) != "Republic of Korea" {
+		t.Fatalf("DisplayName(NameOfficial) for KR failed")
+	}
+
+	if kr.DisplayName(NameISO) != kr.Name {
+		t.Fatalf("DisplayName(NameISO) for KR failed")
+	}
+
+	de, _ := GetByAlpha2("DE")
+
+	if de.DisplayName(NameCommon) != "Germany" {
+		t.Fatalf("DisplayName(NameCommon) fallback for DE failed")
+	}
+}
+
+func TestCoastalCountries(t *testing.T) {
+	var ch, pt bool
+
+	for _, cc := range CoastalCountries() {
+		switch cc.Alpha2 {
+		case "CH":
+			ch = true
+		case "PT":
+			pt = true
+		}
+	}
+
+	if ch {
+		t.Fatalf("Landlocked CH returned as coastal")
+	}
+
+	if !pt {
+		t.Fatalf("Coastal PT missing")
+	}
+}
+
+func TestIsCountryNumeric(t *testing.T) {
+	if !IsCountryNumeric(840) {
+		t.Fatalf("840 not recognized as a country")
+	}
+
+	if IsCountryNumeric(150) {
+		t.Fatalf("150 (Europe) recognized as a country")
+	}
+
+	if !IsCountryNumeric(104) {
+		t.Fatalf("104 (Myanmar) not recognized as a country")
+	}
+}
+
+func TestIndependent(t *testing.T) {
+	fr, _ := GetByAlpha2("FR")
+
+	if !fr.Independent {
+		t.Fatalf("FR not independent")
+	}
+
+	gp, _ := GetByAlpha2("GP")
+
+	if gp.Independent {
+		t.Fatalf("GP independent")
+	}
+
+	if n := len(IndependentCountries()); n != 194 {
+		t.Fatalf("Expected 194 independent countries, got %d", n)
+	}
+}
+
+func TestResolveAntillesSuccessor(t *testing.T) {
+	islands := map[string]string{
+		"Cura\u00E7ao":   "CW",
+		"Curacao":        "CW",
+		"Sint Maarten":   "SX",
+		"Bonaire":        "BQ",
+		"Saba":           "BQ",
+		"Sint Eustatius": "BQ",
+		"Aruba":          "AW",
+	}
+
+	for island, a2 := range islands {
+		code, ok := ResolveAntillesSuccessor(island)
+
+		if !ok || code.Alpha2 != a2 {
+			t.Fatalf("ResolveAntillesSuccessor(%q) failed", island)
+		}
+	}
+
+	if _, ok := ResolveAntillesSuccessor("Jamaica"); ok {
+		t.Fatalf("ResolveAntillesSuccessor resolved a non-Antilles island")
+	}
+}
+
+func TestBCP47Regions(t *testing.T) {
+	regions := make(map[string]bool)
+
+	for _, region := range BCP47Regions() {
+		regions[region] = true
+	}
+
+	if !regions["US"] || !regions["419"] {
+		t.Fatalf("BCP47Regions missing US or 419")
+	}
+
+	if regions["ZZ"] {
+		t.Fatalf("BCP47Regions contains ZZ")
+	}
+}
+
+func TestGetByNameStripsArticle(t *testing.T) {
+	names := map[string]string{
+		"The Gambia":   "GM",
+		"The Bahamas":  "BS",
+		"Bahamas, The": "BS",
+		"Gambia":       "GM",
+	}
+
+	for name, a2 := range names {
+		code, ok := GetByName(name)
+
+		if !ok || code.Alpha2 != a2 {
+			t.Fatalf("GetByName(%q) failed", name)
+		}
+	}
+}
+
+func TestExampleE164(t *testing.T) {
+	us, _ := GetByAlpha2("US")
+	example, ok := us.ExampleE164()
+
+	if !ok || !strings.HasPrefix(example, "+1") || len(example) != 12 {
+		t.Fatalf("ExampleE164 for US failed: %q", example)
+	}
+
+	for a2, example := range example_e164 {
+		code, _ := GetByAlpha2(a2)
+
+		if !strings.HasPrefix(example, code.DialingCode) || len(example) > 16 {
+			t.Fatalf("ExampleE164 for %s is malformed: %q", a2, example)
+		}
+	}
+
+	bv, _ := GetByAlpha2("BV")
+
+	if _, ok := bv.ExampleE164(); ok {
+		t.Fatalf("ExampleE164 for BV returned an example")
+	}
+}
+
+func TestClassifyInput(t *testing.T) {
+	kinds := map[string]InputKind{
+		"US":                 KindAlpha2,
+		"usa":                KindAlpha3,
+		"840":                KindNumeric,
+		"Germany":            KindName,
+		"Korea, Republic of": KindName,
+		"":                   KindUnknown,
+		"12345":              KindUnknown,
+		"U5":                 KindUnknown,
+	}
+
+	for input, kind := range kinds {
+		if got := ClassifyInput(input); got != kind {
+			t.Fatalf("ClassifyInput(%q) = %d, expected %d", input, got, kind)
+		}
+	}
+}
+
+func TestDialing(t *testing.T) {
+	do, _ := GetByAlpha2("DO")
+	parsed := do.Dialing()
+
+	if parsed.CountryCode != "+1" || strings.Join(parsed.AreaCodes, ",") != "809,829,849" {
+		t.Fatalf("Dialing for DO failed: %+v", parsed)
+	}
+
+	gg, _ := GetByAlpha2("GG")
+	parsed = gg.Dialing()
+
+	if parsed.CountryCode != "+44" || strings.Join(parsed.AreaCodes, ",") != "1481" {
+		t.Fatalf("Dialing for GG failed: %+v", parsed)
+	}
+
+	de, _ := GetByAlpha2("DE")
+	parsed = de.Dialing()
+
+	if parsed.CountryCode != "+49" || len(parsed.AreaCodes) != 0 {
+		t.Fatalf("Dialing for DE failed: %+v", parsed)
+	}
+
+	custom := CountryCode{Alpha2: "QZ", DialingCode: "+44"}
+	if n, ok := custom.CallingCodeInt(); !ok || n != 44 || custom.Dialing().CountryCode != "+44" {
+		t.Fatalf("Dialing ignored the receiver's DialingCode: %d, %v", n, ok)
+	}
+
+	de.DialingCode = "+4999"
+	if n, ok := de.CallingCodeInt(); !ok || n != 4999 {
+		t.Fatalf("Dialing of a modified copy returned stale data: %d, %v", n, ok)
+	}
+}
+
+func TestNumericHistory(t *testing.T) {
+	if history := NumericHistory("MM"); len(history) != 1 || history[0] != 104 {
+		t.Fatalf("NumericHistory for MM failed: %v", history)
+	}
+
+	if history := NumericHistory("US"); len(history) != 1 || history[0] != 840 {
+		t.Fatalf("NumericHistory for US failed: %v", history)
+	}
+
+	if history := NumericHistory("SD"); len(history) != 2 || history[0] != 729 || history[1] != 736 {
+		t.Fatalf("NumericHistory for SD failed: %v", history)
+	}
+
+	if history := NumericHistory("EU"); history != nil {
+		t.Fatalf("NumericHistory for EU failed: %v", history)
+	}
+}
+
+func TestNewAllowValidator(t *testing.T) {
+	validate := NewAllowValidator("CU")
+
+	for _, input := range []string{"CU", "cu", "CUB", " cub "} {
+		if err := validate(input); !errors.Is(err, ErrDenied) {
+			t.Fatalf("Validator accepted denied %q", input)
+		}
+	}
+
+	if err := validate("us"); err != nil {
+		t.Fatalf("Validator rejected US: %v", err)
+	}
+
+	if err := validate("QQ"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Validator did not reject unknown QQ as unknown")
+	}
+
+	sanctions := NewAllowValidator("GB", "RU")
+	for _, input := range []string{"GB", "GBR", "UK", "RU", "rus", "SU", "SUN"} {
+		if err := sanctions(input); !errors.Is(err, ErrDenied) {
+			t.Fatalf("Validator denying GB and RU accepted %q: %v", input, err)
+		}
+	}
+
+	for _, input := range []string{"FR", "IE", "UA"} {
+		if err := sanctions(input); err != nil {
+			t.Fatalf("Validator denying GB and RU rejected %q: %v", input, err)
+		}
+	}
+
+	if _, err := NewAllowValidatorErr("CU", "Cuba"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("NewAllowValidatorErr accepted an unknown denied code: %v", err)
+	}
+
+	stale := NewAllowValidator("CU", "Cuba")
+	for _, input := range []string{"US", "CU"} {
+		if err := stale(input); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Validator with an unknown denied code accepted %q: %v", input, err)
+		}
+	}
+}
+
+func TestCapitalTimezone(t *testing.T) {
+	zones := map[string]string{
+		"US": "America/New_York",
+		"JP": "Asia/Tokyo",
+		"RU": "Europe/Moscow",
+	}
+
+	for a2, zone := range zones {
+		code, _ := GetByAlpha2(a2)
+
+		if tz, ok := code.CapitalTimezone(); !ok || tz != zone {
+			t.Fatalf("CapitalTimezone for %s failed: %q", a2, tz)
+		}
+	}
+
+	aq, _ := GetByAlpha2("AQ")
+
+	if _, ok := aq.CapitalTimezone(); ok {
+		t.Fatalf("CapitalTimezone for AQ returned a zone")
+	}
+}
+
+func TestAllSorted(t *testing.T) {
+	byName := AllSorted(func(a, b CountryCode) bool {
+		return a.Name < b.Name
+	})
+
+	if len(byName) != len(by_alpha2) || byName[0].Alpha2 != "AF" {
+		t.Fatalf("AllSorted by name failed")
+	}
+
+	byDialing := AllSorted(func(a, b CountryCode) bool {
+		return a.DialingCode < b.DialingCode
+	})
+
+	var first CountryCode
+	for _, cc := range byDialing {
+		if cc.DialingCode != "" {
+			first = cc
+			break
+		}
+	}
+
+	if first.Alpha2 != "CA" {
+		t.Fatalf("AllSorted by dialing code failed: %s first", first.Alpha2)
+	}
+}
+
+func TestMacaoIdentifiers(t *testing.T) {
+	byAlpha2, _ := GetByAlpha2("MO")
+	byAlpha3, _ := GetByAlpha3("MAC")
+	byNumeric, _ := GetByNumeric(446)
+
+	if byAlpha2.Alpha3 != "MAC" || byAlpha2.Numeric != 446 || byAlpha2.DialingCode != "+853" {
+		t.Fatalf("MO data is wrong: %+v", byAlpha2)
+	}
+
+	if byAlpha3.Alpha2 != "MO" || byNumeric.Alpha2 != "MO" {
+		t.Fatalf("MAC/446 do not resolve to Macao")
+	}
+}
+
+func TestAlphaCodesAreASCIIUppercase(t *testing.T) {
+	isUpper := func(s string) bool {
+		for i := 0; i < len(s); i++ {
+			if s[i] < 'A' || s[i] > 'Z' {
+				return false
+			}
+		}
+		return true
+	}
+
+	for key, cc := range by_alpha2 {
+		if len(cc.Alpha2) != 2 || !isUpper(cc.Alpha2) {
+			t.Fatalf("Entry %q has malformed alpha-2 %q", key, cc.Alpha2)
+		}
+
+		if !isUpper(cc.Alpha3) {
+			t.Fatalf("Entry %q has malformed alpha-3 %q", key, cc.Alpha3)
+		}
+	}
+}
+
+func TestCallingCodeInt(t *testing.T) {
+	gb, _ := GetByAlpha2("GB")
+
+	if n, ok := gb.CallingCodeInt(); !ok || n != 44 {
+		t.Fatalf("CallingCodeInt for GB failed: %d", n)
+	}
+
+	ag, _ := GetByAlpha2("AG")
+
+	if n, ok := ag.CallingCodeInt(); !ok || n != 1 {
+		t.Fatalf("CallingCodeInt for AG failed: %d", n)
+	}
+
+	bv, _ := GetByAlpha2("BV")
+
+	if _, ok := bv.CallingCodeInt(); ok {
+		t.Fatalf("CallingCodeInt for BV succeeded")
+	}
+}
+
+func TestGetByDialingCode(t *testing.T) {
+	matches, ok := GetByDialingCode("+1")
+	found := make(map[string]bool)
+
+	for _, cc := range matches {
+		found[cc.Alpha2] = true
+	}
+
+	if !ok || !found["US"] || !found["CA"] || !found["AG"] || !found["DO"] || !found["PR"] {
+		t.Fatalf("GetByDialingCode(+1) failed")
+	}
+
+	matches, ok = GetByDialingCode("1-268")
+
+	if !ok || len(matches) != 1 || matches[0].Alpha2 != "AG" {
+		t.Fatalf("GetByDialingCode(1-268) failed")
+	}
+
+	matches, ok = GetByDialingCode("+1-829")
+
+	if !ok || len(matches) != 1 || matches[0].Alpha2 != "DO" {
+		t.Fatalf("GetByDialingCode(+1-829) failed")
+	}
+
+	matches, ok = GetByDialingCode("599")
+
+	if !ok || len(matches) != 3 {
+		t.Fatalf("GetByDialingCode(599) failed: %d matches", len(matches))
+	}
+
+	if _, ok := GetByDialingCode("+999"); ok {
+		t.Fatalf("GetByDialingCode(+999) succeeded")
+	}
+}
+
+func TestAll(t *testing.T) {
+	all := All()
+
+	if len(all) != Count() || Count() != 268 {
+		t.Fatalf("All returned %d entries, Count %d", len(all), Count())
+	}
+
+	for i := 1; i < len(all); i++ {
+		if all[i-1].Alpha2 >= all[i].Alpha2 {
+			t.Fatalf("All is not sorted by alpha-2 at %s", all[i].Alpha2)
+		}
+	}
+
+	all[0].Name = "Changed"
+
+	if All()[0].Name == "Changed" {
+		t.Fatalf("All returned shared state")
+	}
+}
+
+func TestAssignmentJSON(t *testing.T) {
+	de, _ := GetByAlpha2("DE")
+
+	data, err := json.Marshal(struct{ Assignment Assignment }{de.Assignment})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	if !strings.Contains(string(data), `"Assignment":"OFFICIALLY_ASSIGNED"`) {
+		t.Fatalf("Assignment not marshaled as a string: %s", data)
+	}
+
+	var a Assignment
+
+	if err := json.Unmarshal([]byte(`"TRANSITIONALLY_RESERVED"`), &a); err != nil || a != TRANSITIONALLY_RESERVED {
+		t.Fatalf("Unmarshal of string form failed: %v", err)
+	}
+
+	if err := json.Unmarshal([]byte(`2`), &a); err != nil || a != EXCEPTIONALLY_RESERVED {
+		t.Fatalf("Unmarshal of legacy integer failed: %v", err)
+	}
+
+	if err := json.Unmarshal([]byte(`"BOGUS"`), &a); err == nil {
+		t.Fatalf("Unmarshal of unknown string succeeded")
+	}
+
+	if err := json.Unmarshal([]byte(`42`), &a); err == nil {
+		t.Fatalf("Unmarshal of unknown integer succeeded")
+	}
+}
+
+func TestContinent(t *testing.T) {
+	continents := map[string]string{
+		"DE": "Europe",
+		"KE": "Africa",
+		"JP": "Asia",
+		"BR": "South America",
+		"MX": "North America",
+		"EU": "",
+	}
+
+	for a2, continent := range continents {
+		code, _ := GetByAlpha2(a2)
+
+		if code.Continent != continent {
+			t.Fatalf("Continent for %s is %q, expected %q", a2, code.Continent, continent)
+		}
+	}
+
+	for _, cc := range All() {
+		if cc.Assignment == OFFICIALLY_ASSIGNED && cc.Continent == "" {
+			t.Fatalf("Officially assigned %s has no continent", cc.Alpha2)
+		}
+	}
+
+	europe := AllByContinent("europe")
+	found := false
+
+	for _, cc := range europe {
+		if cc.Alpha2 == "DE" {
+			found = true
+		}
+		if cc.Continent != "Europe" {
+			t.Fatalf("AllByContinent(europe) returned %s", cc.Alpha2)
+		}
+	}
+
+	if !found {
+		t.Fatalf("AllByContinent(europe) missing DE")
+	}
+}
+
+func TestRegionCode(t *testing.T) {
+	de, _ := GetByAlpha2("DE")
+
+	if de.RegionCode != 155 {
+		t.Fatalf("RegionCode for DE is %d", de.RegionCode)
+	}
+
+	contains := func(codes []CountryCode, a2 string) bool {
+		for _, cc := range codes {
+			if cc.Alpha2 == a2 {
+				return true
+			}
+		}
+		return false
+	}
+
+	europe := AllByRegionCode(150)
+
+	if !contains(europe, "DE") || contains(europe, "US") {
+		t.Fatalf("AllByRegionCode(150) failed")
+	}
+
+	latam := AllByRegionCode(419)
+
+	if !contains(latam, "BR") || !contains(latam, "MX") || contains(latam, "US") {
+		t.Fatalf("AllByRegionCode(419) failed")
+	}
+
+	if len(AllByRegionCode(1)) != 248 {
+		t.Fatalf("AllByRegionCode(1) returned %d entries", len(AllByRegionCode(1)))
+	}
+}
+
+func TestFlagEmoji(t *testing.T) {
+	us, _ := GetByAlpha2("US")
+
+	if us.FlagEmoji() != "\U0001F1FA\U0001F1F8" {
+		t.Fatalf("FlagEmoji for US failed: %q", us.FlagEmoji())
+	}
+
+	if FlagEmojiForAlpha2("eu") != "\U0001F1EA\U0001F1FA" {
+		t.Fatalf("FlagEmojiForAlpha2(eu) failed")
+	}
+
+	for _, bad := range []string{"", "U", "USA", "U1", "ÅX"} {
+		if FlagEmojiForAlpha2(bad) != "" {
+			t.Fatalf("FlagEmojiForAlpha2(%q) returned a flag", bad)
+		}
+	}
+}
+
+func TestFindByNameFoldsDiacritics(t *testing.T) {
+	queries := map[string]string{
+		"reunion":  "RE",
+		"R\u00E9u": "RE",
+		"cote d":   "CI",
+		"curacao":  "CW",
+		"aland":    "AX",
+	}
+
+	for query, a2 := range queries {
+		matches := FindByName(query)
+
+		if len(matches) != 1 || matches[0].Alpha2 != a2 {
+			t.Fatalf("FindByName(%q) failed: %v", query, matches)
+		}
+	}
+}
+
+func TestSearchByName(t *testing.T) {
+	matches := SearchByName("korea")
+
+	if len(matches) != 2 || matches[0].Alpha2 != "KP" || matches[1].Alpha2 != "KR" {
+		t.Fatalf("SearchByName(korea) failed: %v", matches)
+	}
+
+	for input, a2 := range map[string]string{"The Gambia": "GM", "the bahamas": "BS", "Gambia, The": "GM"} {
+		if matches := SearchByName(input); len(matches) != 1 || matches[0].Alpha2 != a2 {
+			t.Fatalf("SearchByName(%q) = %v, expected %s", input, matches, a2)
+		}
+	}
+
+	matches = SearchByName("Republic")
+
+	if len(matches) < 10 {
+		t.Fatalf("SearchByName(Republic) found only %d matches", len(matches))
+	}
+
+	for i := 1; i < len(matches); i++ {
+		if matches[i-1].Name > matches[i].Name {
+			t.Fatalf("SearchByName results are not sorted by name")
+		}
+	}
+
+	if len(SearchByName("  ")) != 0 {
+		t.Fatalf("SearchByName matched a blank query")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	if code, err := LookupByAlpha2("DE"); err != nil || code.Name != "Germany" {
+		t.Fatalf("LookupByAlpha2(DE) failed: %v", err)
+	}
+
+	if code, err := LookupByAlpha3("DEU"); err != nil || code.Alpha2 != "DE" {
+		t.Fatalf("LookupByAlpha3(DEU) failed: %v", err)
+	}
+
+	if code, err := LookupByName("Germany"); err != nil || code.Alpha2 != "DE" {
+		t.Fatalf("LookupByName(Germany) failed: %v", err)
+	}
+
+	if code, err := LookupByNumeric(276); err != nil || code.Alpha2 != "DE" {
+		t.Fatalf("LookupByNumeric(276) failed: %v", err)
+	}
+
+	if _, err := LookupByAlpha2("QQ"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("LookupByAlpha2(QQ) did not return ErrNotFound: %v", err)
+	}
+
+	if _, err := LookupByAlpha3("QQQ"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("LookupByAlpha3(QQQ) did not return ErrNotFound: %v", err)
+	}
+
+	if _, err := LookupByName("Atlantis"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("LookupByName(Atlantis) did not return ErrNotFound: %v", err)
+	}
+
+	if _, err := LookupByNumeric(999); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("LookupByNumeric(999) did not return ErrNotFound: %v", err)
+	}
+}
+
+func TestSQL(t *testing.T) {
+	var code CountryCode
+
+	if err := code.Scan("DE"); err != nil || code.Name != "Germany" {
+		t.Fatalf("Scan(string) failed: %v", err)
+	}
+
+	if err := code.Scan([]byte("US")); err != nil || code.Name != "United States" {
+		t.Fatalf("Scan([]byte) failed: %v", err)
+	}
+
+	if value, err := code.Value(); err != nil || value != "US" {
+		t.Fatalf("Value failed: %v %v", value, err)
+	}
+
+	if err := code.Scan(nil); err != nil || code.Alpha2 != "" {
+		t.Fatalf("Scan(nil) failed: %v", err)
+	}
+
+	if value, err := code.Value(); err != nil || value != nil {
+		t.Fatalf("Value of zero CountryCode is not NULL: %v %v", value, err)
+	}
+
+	if err := code.Scan("QQ"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Scan(QQ) did not fail with ErrNotFound: %v", err)
+	}
+
+	if err := code.Scan(3.5); err == nil {
+		t.Fatalf("Scan(float64) succeeded")
+	}
+
+	for _, src := range []interface{}{int64(840), "US", "USA", []byte("USA"), "840", "us", []byte("us "), "usa"} {
+		code = CountryCode{}
+		if err := code.Scan(src); err != nil || code.Alpha2 != "US" {
+			t.Fatalf("Scan(%#v) = %s, %v, want US", src, code.Alpha2, err)
+		}
+	}
+
+	for _, src := range []interface{}{int64(999), int64(-1), "QQQ"} {
+		if err := code.Scan(src); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Scan(%#v) did not fail with ErrNotFound: %v", src, err)
+		}
+	}
+
+	for _, src := range []interface{}{"U", "United States", "US-CA"} {
+		if err := code.Scan(src); err == nil || errors.Is(err, ErrNotFound) {
+			t.Fatalf("Scan(%#v) was not rejected as ambiguous: %v", src, err)
+		}
+	}
+}
+
+func TestGetByHistoricalAlpha3(t *testing.T) {
+	historical := map[string]string{
+		"ANHH": "AN",
+		"BUMM": "BU",
+		"CSXX": "CS",
+		"NTHH": "NT",
+		"TPTL": "TP",
+		"YUCS": "YU",
+		"ZRCD": "ZR",
+	}
+
+	for a4, a2 := range historical {
+		if code, ok := GetByHistoricalAlpha3(a4); !ok || code.Alpha2 != a2 {
+			t.Fatalf("GetByHistoricalAlpha3(%q) failed", a4)
+		}
+
+		if _, ok := GetByAlpha3(a4); ok {
+			t.Fatalf("GetByAlpha3(%q) matched a four-letter code", a4)
+		}
+	}
+
+	for _, cc := range All() {
+		switch len(cc.Alpha3) {
+		case 0, 3:
+		case 4:
+			if cc.Assignment != TRANSITIONALLY_RESERVED {
+				t.Fatalf("%s has a four-letter alpha-3 but is %v", cc.Alpha2, cc.Assignment)
+			}
+		default:
+			t.Fatalf("%s has a malformed alpha-3 %q", cc.Alpha2, cc.Alpha3)
+		}
+	}
+
+	for _, a4 := range []string{"yucs", " YUCS ", "Yucs"} {
+		if code, ok := GetByHistoricalAlpha3(a4); !ok || code.Alpha2 != "YU" {
+			t.Fatalf("GetByHistoricalAlpha3(%q) failed", a4)
+		}
+	}
+
+	if _, ok := GetByHistoricalAlpha3("USA"); ok {
+		t.Fatalf("GetByHistoricalAlpha3 matched a three-letter code")
+	}
+}
+
+func TestSuccessor(t *testing.T) {
+	replaced := map[string]string{
+		"BU": "MM",
+		"ZR": "CD",
+		"TP": "TL",
+		"SF": "FI",
+		"AN": "CW",
+	}
+
+	for old, current := range replaced {
+		if code, ok := SuccessorOf(old); !ok || code.Alpha2 != current {
+			t.Fatalf("SuccessorOf(%q) failed", old)
+		}
+	}
+
+	an, _ := GetByAlpha2("AN")
+
+	if successors := an.Successors(); len(successors) != 3 {
+		t.Fatalf("AN has %d successors", len(successors))
+	}
+
+	if _, ok := SuccessorOf("DE"); ok {
+		t.Fatalf("SuccessorOf(DE) returned a successor")
+	}
+
+	for old, codes := range successors {
+		for _, a2 := range codes {
+			if code, ok := GetByAlpha2(a2); !ok || code.Assignment != OFFICIALLY_ASSIGNED {
+				t.Fatalf("Successor %s of %s is not officially assigned", a2, old)
+			}
+		}
+	}
+}
+
+func TestCurrency(t *testing.T) {
+	currencies := map[string]string{
+		"DE": "EUR",
+		"JP": "JPY",
+		"GB": "GBP",
+		"EC": "USD",
+	}
+
+	for a2, currency := range currencies {
+		code, _ := GetByAlpha2(a2)
+
+		if code.CurrencyCode != currency {
+			t.Fatalf("CurrencyCode for %s is %q", a2, code.CurrencyCode)
+		}
+	}
+
+	gb, _ := GetByAlpha2("GB")
+
+	if gb.CurrencyNumeric != 826 {
+		t.Fatalf("CurrencyNumeric for GB is %d", gb.CurrencyNumeric)
+	}
+
+	for _, cc := range All() {
+		if cc.Assignment == OFFICIALLY_ASSIGNED && cc.Alpha2 != "AQ" && (cc.CurrencyCode == "" || cc.CurrencyNumeric == 0) {
+			t.Fatalf("Officially assigned %s has no currency", cc.Alpha2)
+		}
+	}
+
+	usd := AllByCurrency("usd")
+	found := make(map[string]bool)
+
+	for _, cc := range usd {
+		found[cc.Alpha2] = true
+	}
+
+	if !found["US"] || !found["EC"] || !found["PR"] || found["CA"] {
+		t.Fatalf("AllByCurrency(usd) failed")
+	}
+}
+
+// TestConcurrentReads is most useful under "go test -race".
+func TestConcurrentReads(t *testing.T) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				if _, ok := GetByAlpha2("DE"); !ok {
+					t.Errorf("GetByAlpha2(DE) failed")
+				}
+
+				if len(FindByName("United")) == 0 {
+					t.Errorf("FindByName(United) failed")
+				}
+
+				if len(All()) != Count() {
+					t.Errorf("All and Count disagree")
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestRegister(t *testing.T) {
+	defer func() {
+		delete(by_alpha2, "ZZ")
+		buildIndexes()
+	}()
+
+	test := CountryCode{
+		Name:       "Testland",
+		Alpha2:     "ZZ",
+		Alpha3:     "ZZZ",
+		Numeric:    999,
+		Assignment: USER_ASSIGNED,
+	}
+
+	if err := Register(test); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	if code, ok := GetByAlpha3("ZZZ"); !ok || code.Name != "Testland" {
+		t.Fatalf("Registered entry not found by alpha-3")
+	}
+
+	if matches := FindByName("testl"); len(matches) != 1 || matches[0].Alpha2 != "ZZ" {
+		t.Fatalf("Registered entry not found by name")
+	}
+
+	test.Name = "Renamed Testland"
+
+	if err := Register(test); err != nil {
+		t.Fatalf("Re-registering a user-assigned code failed: %v", err)
+	}
+
+	if len(FindByName("testl")) != 0 {
+		t.Fatalf("Stale name left in the trie after re-registering")
+	}
+
+	if err := Register(CountryCode{Name: "Germany 2", Alpha2: "DE", Assignment: USER_ASSIGNED}); err == nil {
+		t.Fatalf("Register replaced an officially assigned code")
+	}
+
+	if err := Register(CountryCode{Name: "Clash", Alpha2: "QX", Alpha3: "DEU", Assignment: USER_ASSIGNED}); err == nil {
+		t.Fatalf("Register accepted a colliding alpha-3")
+	}
+
+	if err := Register(CountryCode{Name: "Bad", Alpha2: "q1"}); err == nil {
+		t.Fatalf("Register accepted a malformed alpha-2")
+	}
+}
+
+func TestDatasetConsistency(t *testing.T) {
+	numerics := make(map[int]CountryCode)
+	alpha3s := make(map[string]string)
+	noAlpha3 := make([]string, 0)
+
+	for a2, cc := range by_alpha2 {
+		if cc.Alpha2 != a2 {
+			t.Fatalf("Entry keyed %s has Alpha2 %s", a2, cc.Alpha2)
+		}
+
+		if cc.Alpha3 == "" {
+			noAlpha3 = append(noAlpha3, a2)
+		}
+
+		if cc.HasAlpha3() != (len(cc.Alpha3) == 3) {
+			t.Fatalf("HasAlpha3 wrong for %s", a2)
+		}
+
+		if cc.Numeric > 0 {
+			if other, ok := numerics[cc.Numeric]; ok && !sharesNumericWithSuccessor(cc, other) {
+				t.Fatalf("Numeric %d shared by %s and %s", cc.Numeric, cc.Alpha2, other.Alpha2)
+			}
+			numerics[cc.Numeric] = cc
+		}
+
+		if cc.Assignment == OFFICIALLY_ASSIGNED {
+			if other, ok := alpha3s[cc.Alpha3]; ok {
+				t.Fatalf("Alpha3 %s shared by %s and %s", cc.Alpha3, cc.Alpha2, other)
+			}
+			alpha3s[cc.Alpha3] = cc.Alpha2
+		}
+	}
+
+	sort.Strings(noAlpha3)
+	if strings.Join(noAlpha3, ",") != "EA,EU,IC,UK" {
+		t.Fatalf("Unexpected entries without an alpha-3: %v", noAlpha3)
+	}
+}
+
+// sharesNumericWithSuccessor reports whether one of a and b is a reserved
+// code that kept the numeric code of the entry that replaced it, such as
+// BU (Burma) and MM (Myanmar).
+func sharesNumericWithSuccessor(a, b CountryCode) bool {
+	for _, pair := range [][2]CountryCode{{a, b}, {b, a}} {
+		if s, ok := pair[0].Successor(); ok && s.Alpha2 == pair[1].Alpha2 {
+			return true
+		}
+	}
+	return false
+}
+
+func TestGetByTLD(t *testing.T) {
+	for tld, a2 := range map[string]string{"de": "DE", ".US": "US", ".uk": "GB", "eu": "EU"} {
+		if code, ok := GetByTLD(tld); !ok || code.Alpha2 != a2 {
+			t.Fatalf("GetByTLD(%q) returned %s, expected %s", tld, code.Alpha2, a2)
+		}
+	}
+
+	if gb, _ := GetByAlpha2("GB"); gb.TLD != ".uk" {
+		t.Fatalf("Expected GB to use .uk, got %q", gb.TLD)
+	}
+
+	if mf, _ := GetByAlpha2("MF"); mf.TLD != "" {
+		t.Fatalf("Expected MF to have no ccTLD, got %q", mf.TLD)
+	}
+
+	if _, ok := GetByTLD(".gb"); ok {
+		t.Fatalf("Found an entry for .gb, expected GB to use .uk only")
+	}
+}
+
+func TestFindByNameSorted(t *testing.T) {
+	matches := FindByNameSorted("ma")
+
+	if len(matches) < 2 {
+		t.Fatalf("Expected several matches for \"ma\", got %d", len(matches))
+	}
+
+	for i := 1; i < len(matches); i++ {
+		if matches[i-1].Name > matches[i].Name {
+			t.Fatalf("Matches not sorted: %q before %q", matches[i-1].Name, matches[i].Name)
+		}
+	}
+
+	limited := FindByNameLimit("ma", 3)
+
+	if len(limited) != 3 || limited[0].Alpha2 != matches[0].Alpha2 || limited[2].Alpha2 != matches[2].Alpha2 {
+		t.Fatalf("FindByNameLimit did not return the first 3 sorted matches")
+	}
+
+	if len(FindByNameLimit("ma", -1)) != len(matches) {
+		t.Fatalf("FindByNameLimit with a negative limit truncated the results")
+	}
+}
+
+func TestEqualAndIsZero(t *testing.T) {
+	fi, _ := GetByAlpha2("FI")
+	sf, _ := GetByAlpha2("SF")
+
+	if fi.Equal(sf) {
+		t.Fatalf("FI and SF compared equal")
+	}
+
+	if byName, _ := GetByNumeric(246); !fi.Equal(byName) {
+		t.Fatalf("FI did not equal itself")
+	}
+
+	if fi.IsZero() {
+		t.Fatalf("FI reported as zero")
+	}
+
+	if missing, _ := GetByAlpha2("??"); !missing.IsZero() {
+		t.Fatalf("Missing entry not reported as zero")
+	}
+}
+
+func TestDisplayNameNatural(t *testing.T) {
+	expected := map[string]string{
+		"KR": "Republic of Korea",
+		"BO": "Plurinational State of Bolivia",
+		"CD": "Democratic Republic of the Congo",
+		"MK": "Former Yugoslav Republic of Macedonia",
+		"BQ": "Bonaire, Sint Eustatius and Saba",
+		"EA": "Ceuta, Melilla",
+		"DE": "Germany",
+		"VG": "British Virgin Islands",
+		"VI": "U.S. Virgin Islands",
+		"FX": "Metropolitan France",
+	}
+
+	for a2, name := range expected {
+		code, _ := GetByAlpha2(a2)
+
+		if got := code.DisplayName(NameNatural); got != name {
+			t.Fatalf("DisplayName(NameNatural) for %s returned %q, expected %q", a2, got, name)
+		}
+	}
+}
+
+func TestGetByCommonName(t *testing.T) {
+	expected := map[string]string{
+		"South Korea":   "KR",
+		"north korea":   "KP",
+		"Russia":        "RU",
+		"USA":           "US",
+		"America":       "US",
+		"UK":            "GB",
+		"Britain":       "GB",
+		"Vietnam":       "VN",
+		"viet nam":      "VN",
+		"Ivory Coast":   "CI",
+		"cote d'ivoire": "CI",
+		"The Gambia":    "GM",
+		"FINLAND":       "FI",
+	}
+
+	for name, a2 := range expected {
+		if code, ok := GetByCommonName(name); !ok || code.Alpha2 != a2 {
+			t.Fatalf("GetByCommonName(%q) returned %s, expected %s", name, code.Alpha2, a2)
+		}
+	}
+
+	if _, ok := GetByCommonName("Atlantis"); ok {
+		t.Fatalf("GetByCommonName found Atlantis")
+	}
+}
+
+func TestCallingCodes(t *testing.T) {
+	pr, _ := GetByAlpha2("PR")
+	if codes := pr.CallingCodes(); len(codes) != 2 || codes[0] != "+1-787" || codes[1] != "+1-939" {
+		t.Fatalf("Unexpected calling codes for PR: %v", codes)
+	}
+
+	tg, _ := GetByAlpha2("TG")
+	if tg.DialingCode != "+228" {
+		t.Fatalf("Expected TG dialing code +228, got %q", tg.DialingCode)
+	}
+
+	for _, cc := range All() {
+		for _, code := range cc.CallingCodes() {
+			if !strings.HasPrefix(code, "+") || strings.TrimSpace(code) != code {
+				t.Fatalf("Malformed calling code %q for %s", code, cc.Alpha2)
+			}
+		}
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	if !IsValidAlpha2("de") || !IsValidAlpha2("DE") || IsValidAlpha2("QQ") {
+		t.Fatalf("IsValidAlpha2 failed")
+	}
+
+	if !IsValidAlpha3("deu") || !IsValidAlpha3("DEU") || IsValidAlpha3("QQQ") {
+		t.Fatalf("IsValidAlpha3 failed")
+	}
+
+	if !IsValidNumeric(276) || IsValidNumeric(0) || IsValidNumeric(-1) || IsValidNumeric(1000) {
+		t.Fatalf("IsValidNumeric failed")
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	ch, _ := GetByAlpha2("CH")
+	if strings.Join(ch.Languages(), ",") != "de,fr,it,rm" {
+		t.Fatalf("Unexpected languages for CH: %v", ch.Languages())
+	}
+
+	for _, cc := range All() {
+		hasData := len(cc.Languages()) > 0
+		expected := cc.Assignment == OFFICIALLY_ASSIGNED && cc.Alpha2 != "AQ" && cc.Alpha2 != "BV" && cc.Alpha2 != "HM"
+
+		if hasData != expected {
+			t.Fatalf("Unexpected language data for %s: %v", cc.Alpha2, cc.Languages())
+		}
+	}
+
+	french := AllByLanguage("FR")
+	found := make(map[string]bool)
+	for _, cc := range french {
+		found[cc.Alpha2] = true
+	}
+
+	if !found["BE"] || !found["CA"] || !found["CH"] || found["DE"] {
+		t.Fatalf("Unexpected AllByLanguage(\"FR\") results")
+	}
+}
+
+func TestCountryCodeJSON(t *testing.T) {
+	for _, input := range []string{`"DE"`, `"de"`, `"DEU"`, `"deu"`, `276`} {
+		var code CountryCode
+
+		if err := json.Unmarshal([]byte(input), &code); err != nil || code.Alpha2 != "DE" {
+			t.Fatalf("Unmarshal(%s) returned %s, %v", input, code.Alpha2, err)
+		}
+	}
+
+	for _, input := range []string{`"QQ"`, `"Germany"`, `999`, `0`, `true`} {
+		var code CountryCode
+
+		if err := json.Unmarshal([]byte(input), &code); err == nil {
+			t.Fatalf("Unmarshal(%s) accepted an unknown code", input)
+		}
+	}
+
+	de, _ := GetByAlpha2("DE")
+
+	data, err := json.Marshal(struct {
+		Country CountryCode
+		Missing CountryCode
+	}{Country: de})
+	if err != nil || string(data) != `{"Country":"DE","Missing":null}` {
+		t.Fatalf("Unexpected JSON %s, %v", data, err)
+	}
+}
+
+func TestGroups(t *testing.T) {
+	expected := map[string]int{"EU": 27, "eea": 30, "Schengen": 29, "NATO": 32, "G7": 7, "Mercosur": 0}
+
+	for group, n := range expected {
+		if got := len(AllInGroup(group)); got != n {
+			t.Fatalf("AllInGroup(%q) returned %d members, expected %d", group, got, n)
+		}
+	}
+
+	de, _ := GetByAlpha2("DE")
+	eu, _ := GetByAlpha2("EU")
+	no, _ := GetByAlpha2("NO")
+
+	if !de.IsEUMember() || eu.IsEUMember() || no.IsEUMember() {
+		t.Fatalf("IsEUMember failed")
+	}
+}
+
+func TestGetByAlpha2Ptr(t *testing.T) {
+	de, ok := GetByAlpha2Ptr("DE")
+	if !ok || de.Name != "Germany" {
+		t.Fatalf("GetByAlpha2Ptr failed")
+	}
+
+	if again, _ := GetByAlpha2Ptr("DE"); again != de {
+		t.Fatalf("GetByAlpha2Ptr returned a different pointer for the same entry")
+	}
+
+	if _, ok := GetByAlpha2Ptr("QQ"); ok {
+		t.Fatalf("GetByAlpha2Ptr found QQ")
+	}
+}
+
+var benchName string
+
+func BenchmarkGetByAlpha2(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		code, _ := GetByAlpha2("DE")
+		benchName = code.Name
+	}
+}
+
+func BenchmarkGetByAlpha2Ptr(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		code, _ := GetByAlpha2Ptr("DE")
+		benchName = code.Name
+	}
+}
+
+// BenchmarkBuildIndexes measures the start-up cost that programs using only
+// alpha-2 lookups no longer pay.
+func BenchmarkBuildIndexes(b *testing.B) {
+	ensureIndexes()
+
+	for i := 0; i < b.N; i++ {
+		buildIndexes()
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	for a2, canonical := range map[string]string{"FI": "FI", "SF": "FI", "UK": "GB", "GB": "GB", "SU": "SU"} {
+		code, _ := GetByAlpha2(a2)
+
+		if got := code.Canonical(); got.Alpha2 != canonical {
+			t.Fatalf("Canonical of %s returned %s, expected %s", a2, got.Alpha2, canonical)
+		}
+	}
+}
+
+func TestFindByNameMap(t *testing.T) {
+	matches := FindByNameMap("united")
+
+	if len(matches) != len(FindByName("united")) {
+		t.Fatalf("FindByNameMap and FindByName disagree")
+	}
+
+	if us, ok := matches["US"]; !ok || us.Name != "United States" {
+		t.Fatalf("US missing from FindByNameMap(\"united\")")
+	}
+}
+
+func TestAssignmentPredicates(t *testing.T) {
+	de, _ := GetByAlpha2("DE")
+	uk, _ := GetByAlpha2("UK")
+	yu, _ := GetByAlpha2("YU")
+	xk, _ := GetByAlpha2("XK")
+
+	if !de.IsOfficiallyAssigned() || de.IsReserved() || de.IsUserAssigned() {
+		t.Fatalf("Unexpected predicates for DE")
+	}
+
+	if uk.IsOfficiallyAssigned() || !uk.IsReserved() || !yu.IsReserved() {
+		t.Fatalf("Unexpected predicates for reserved entries")
+	}
+
+	if !xk.IsUserAssigned() || xk.IsReserved() {
+		t.Fatalf("Unexpected predicates for XK")
+	}
+}
+
+func TestParseAssignment(t *testing.T) {
+	expected := map[string]Assignment{
+		"Officially assigned":     OFFICIALLY_ASSIGNED,
+		"OFFICIALLY_ASSIGNED":     OFFICIALLY_ASSIGNED,
+		"transitionally reserved": TRANSITIONALLY_RESERVED,
+		" not_used ":              NOT_USED,
+	}
+
+	for s, a := range expected {
+		if got, err := ParseAssignment(s); err != nil || got != a {
+			t.Fatalf("ParseAssignment(%q) returned %v, %v", s, got, err)
+		}
+	}
+
+	for _, a := range []Assignment{USER_ASSIGNED, EXCEPTIONALLY_RESERVED, INDETERMINATELY_RESERVED} {
+		if got, err := ParseAssignment(a.String()); err != nil || got != a {
+			t.Fatalf("ParseAssignment did not round-trip %v", a)
+		}
+	}
+
+	if _, err := ParseAssignment("Bogus"); err == nil {
+		t.Fatalf("ParseAssignment accepted an unknown value")
+	}
+}
+
+func TestSubdivisions(t *testing.T) {
+	if name, ok := GetBySubdivision("US", "CA"); !ok || name != "California" {
+		t.Fatalf("GetBySubdivision(US, CA) returned %q", name)
+	}
+
+	if name, ok := GetBySubdivision("us", "us-ny"); !ok || name != "New York" {
+		t.Fatalf("GetBySubdivision(us, us-ny) returned %q", name)
+	}
+
+	if _, ok := GetBySubdivision("US", "ZZ"); ok {
+		t.Fatalf("GetBySubdivision found US-ZZ")
+	}
+
+	if subs, ok := GetSubdivisions("DE"); !ok || len(subs) != 16 {
+		t.Fatalf("Expected 16 subdivisions for DE, got %d", len(subs))
+	}
+
+	if _, ok := GetSubdivisions("FR"); ok {
+		t.Fatalf("Unexpected subdivision data for FR")
+	}
+
+	us, _ := GetSubdivisions("us")
+	delete(us, "US-CA")
+	if name, ok := GetBySubdivision("US", "CA"); !ok || name != "California" {
+		t.Fatalf("Modifying GetSubdivisions' result changed the dataset")
+	}
+}
+
+func TestBorders(t *testing.T) {
+	de, _ := GetByAlpha2("DE")
+	if strings.Join(de.Borders(), ",") != "AT,BE,CH,CZ,DK,FR,LU,NL,PL" {
+		t.Fatalf("Unexpected borders for DE: %v", de.Borders())
+	}
+
+	if neighbors := de.NeighborCodes(); len(neighbors) != 9 || neighbors[0].Alpha2 != "AT" {
+		t.Fatalf("Unexpected NeighborCodes for DE")
+	}
+
+	for _, a2 := range []string{"JP", "AQ", "IS"} {
+		if code, _ := GetByAlpha2(a2); len(code.Borders()) != 0 {
+			t.Fatalf("Unexpected borders for %s: %v", a2, code.Borders())
+		}
+	}
+
+	for _, cc := range All() {
+		for _, a2 := range cc.Borders() {
+			other, ok := GetByAlpha2(a2)
+			if !ok {
+				t.Fatalf("%s borders unknown code %s", cc.Alpha2, a2)
+			}
+
+			if !strings.Contains(strings.Join(other.Borders(), ","), cc.Alpha2) {
+				t.Fatalf("%s borders %s but not the other way round", cc.Alpha2, a2)
+			}
+		}
+	}
+}
+
+func TestFindByNameEmptyPrefix(t *testing.T) {
+	if matches := FindByName(""); len(matches) != 0 {
+		t.Fatalf("FindByName(\"\") returned %d matches", len(matches))
+	}
+
+	if matches := FindByName("   "); len(matches) != 0 {
+		t.Fatalf("FindByName(\"   \") returned %d matches", len(matches))
+	}
+
+	if matches := FindByName("Germ"); len(matches) != 1 || matches[0].Alpha2 != "DE" {
+		t.Fatalf("FindByName(\"Germ\") failed")
+	}
+}
+
+func TestWorldRegion(t *testing.T) {
+	expected := map[string]WorldRegion{
+		"DE": RegionEurope,
+		"US": RegionAmericas,
+		"BR": RegionAmericas,
+		"JP": RegionAsia,
+		"NG": RegionAfrica,
+		"FJ": RegionOceania,
+		"AQ": RegionNone,
+		"UK": RegionNone,
+	}
+
+	for a2, region := range expected {
+		if code, _ := GetByAlpha2(a2); code.Region != region {
+			t.Fatalf("Expected %s in %v, got %v", a2, region, code.Region)
+		}
+	}
+
+	total := 0
+	for r := RegionAfrica; r <= RegionOceania; r++ {
+		total += len(AllByRegion(r))
+	}
+
+	if total != 248 {
+		t.Fatalf("Expected 248 entries across the world regions, got %d", total)
+	}
+
+	if RegionAmericas.String() != "Americas" || WorldRegion(42).String() != "WorldRegion(42)" {
+		t.Fatalf("WorldRegion.String failed")
+	}
+}
+
+func TestLocalizedName(t *testing.T) {
+	de, _ := GetByAlpha2("DE")
+
+	expected := map[string]string{"fr": "Allemagne", "ES": "Alemania", "de": "Deutschland", "it": "Germany"}
+	for lang, name := range expected {
+		if got := de.LocalizedName(lang); got != name {
+			t.Fatalf("LocalizedName(%q) returned %q, expected %q", lang, got, name)
+		}
+	}
+
+	for _, test := range []struct{ lang, name, a2 string }{
+		{"fr", "allemagne", "DE"},
+		{"fr", "Etats-Unis", "US"},
+		{"es", "Reino Unido", "GB"},
+		{"de", "Vereinigtes K\u00F6nigreich", "GB"},
+		{"fr", "Canada", "CA"},
+	} {
+		if code, ok := GetByLocalizedName(test.lang, test.name); !ok || code.Alpha2 != test.a2 {
+			t.Fatalf("GetByLocalizedName(%q, %q) returned %s, expected %s", test.lang, test.name, code.Alpha2, test.a2)
+		}
+	}
+
+	if _, ok := GetByLocalizedName("fr", "Germany 2"); ok {
+		t.Fatalf("GetByLocalizedName matched an unknown name")
+	}
+}
+
+func TestForEach(t *testing.T) {
+	all := All()
+	i := 0
+
+	ForEach(func(cc CountryCode) bool {
+		if !cc.Equal(all[i]) {
+			t.Fatalf("ForEach visited %s at position %d, expected %s", cc.Alpha2, i, all[i].Alpha2)
+		}
+		i++
+		return true
+	})
+
+	if i != len(all) {
+		t.Fatalf("ForEach visited %d entries, expected %d", i, len(all))
+	}
+
+	var first CountryCode
+	ForEach(func(cc CountryCode) bool {
+		if strings.HasPrefix(cc.Name, "Ger") {
+			first = cc
+			return false
+		}
+		return true
+	})
+
+	if first.Alpha2 != "DE" {
+		t.Fatalf("ForEach did not stop at the first match")
+	}
+}
+
+func TestKnownNumericCodes(t *testing.T) {
+	expected := map[string]int{"DE": 276, "US": 840, "AD": 20, "AS": 16, "MM": 104, "FI": 246, "GB": 826, "MO": 446}
+
+	for a2, numeric := range expected {
+		if code, _ := GetByAlpha2(a2); code.Numeric != numeric {
+			t.Fatalf("Expected %s to have numeric %d, got %d", a2, numeric, code.Numeric)
+		}
+
+		if code, _ := GetByNumeric(numeric); code.Alpha2 != a2 {
+			t.Fatalf("GetByNumeric(%d) returned %s, expected %s", numeric, code.Alpha2, a2)
+		}
+	}
+}
+
+func TestLabel(t *testing.T) {
+	de, _ := GetByAlpha2("DE")
+
+	if de.Label() != "Germany (DE)" {
+		t.Fatalf("Unexpected label %q", de.Label())
+	}
+
+	if s := fmt.Sprintf("%v|%s", de, de); s != "Germany (DE)|Germany (DE)" {
+		t.Fatalf("Unexpected formatting %q", s)
+	}
+
+	if (CountryCode{}).String() != "" {
+		t.Fatalf("Zero CountryCode has a non-empty String")
+	}
+}
+
+func TestCountByAssignment(t *testing.T) {
+	counts := CountByAssignment()
+
+	if counts[OFFICIALLY_ASSIGNED] != 249 || counts[EXCEPTIONALLY_RESERVED] != 10 ||
+		counts[TRANSITIONALLY_RESERVED] != 8 || counts[USER_ASSIGNED] != 1 {
+		t.Fatalf("Unexpected assignment counts: %v", counts)
+	}
+
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+
+	if total != Count() {
+		t.Fatalf("Assignment counts add up to %d, expected %d", total, Count())
+	}
+}
+
+func TestGetByNumericString(t *testing.T) {
+	for s, a2 := range map[string]string{"276": "DE", "004": "AF", "4": "AF", " 840 ": "US"} {
+		if code, ok := GetByNumericString(s); !ok || code.Alpha2 != a2 {
+			t.Fatalf("GetByNumericString(%q) returned %s, expected %s", s, code.Alpha2, a2)
+		}
+	}
+
+	for _, s := range []string{"", "000", "0", "-1", "abc", "2760", "999"} {
+		if code, ok := GetByNumericString(s); ok {
+			t.Fatalf("GetByNumericString(%q) matched %s", s, code.Alpha2)
+		}
+	}
+}
+
+func TestAllIndependent(t *testing.T) {
+	independent := AllIndependent()
+
+	if len(independent) != 194 {
+		t.Fatalf("Expected 194 independent entries, got %d", len(independent))
+	}
+
+	for _, a2 := range []string{"GU", "PR", "BM", "TW", "PS", "XK"} {
+		if code, _ := GetByAlpha2(a2); code.Independent {
+			t.Fatalf("%s marked independent", a2)
+		}
+	}
+
+	if va, _ := GetByAlpha2("VA"); !va.Independent {
+		t.Fatalf("VA not marked independent")
+	}
+}
+
+func TestGetManyByAlpha2(t *testing.T) {
+	found, missing := GetManyByAlpha2([]string{"de", " US ", "QQ", "FR", ""})
+
+	if len(found) != 3 || found[0].Alpha2 != "DE" || found[1].Alpha2 != "US" || found[2].Alpha2 != "FR" {
+		t.Fatalf("Unexpected entries found: %v", found)
+	}
+
+	if len(missing) != 2 || missing[0] != "QQ" || missing[1] != "" {
+		t.Fatalf("Unexpected missing codes: %q", missing)
+	}
+}
+
+func TestInferFromE164(t *testing.T) {
+	expected := map[string]string{
+		"+4930123456":      "DE",
+		"+12685551234":     "AG",
+		"+1 (212) 5551234": "US",
+		"+17875551234":     "PR",
+		"+74951234567":     "RU",
+		"+441212345678":    "GB",
+		"+35891234567":     "FI",
+		"22890123456":      "TG",
+		"+59994612345":     "CW",
+	}
+
+	for number, a2 := range expected {
+		if code, ok := InferFromE164(number); !ok || code.Alpha2 != a2 {
+			t.Fatalf("InferFromE164(%q) returned %s, expected %s", number, code.Alpha2, a2)
+		}
+	}
+
+	for _, number := range []string{"", "+", "not a number", "+0123"} {
+		if code, ok := InferFromE164(number); ok {
+			t.Fatalf("InferFromE164(%q) matched %s", number, code.Alpha2)
+		}
+	}
+}
+
+func TestMarshalDataset(t *testing.T) {
+	data, err := MarshalDataset()
+	if err != nil {
+		t.Fatalf("MarshalDataset failed: %v", err)
+	}
+
+	var entries []map[string]interface{}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatalf("MarshalDataset produced invalid JSON: %v", err)
+	}
+
+	if len(entries) != Count() || entries[0]["alpha2"] != "AC" {
+		t.Fatalf("Unexpected dataset export")
+	}
+
+	for _, entry := range entries {
+		if entry["alpha2"] == "DE" {
+			if entry["assignment"] != "OFFICIALLY_ASSIGNED" || entry["region"] != "Europe" || entry["numeric"] != 276.0 {
+				t.Fatalf("Unexpected export for DE: %v", entry)
+			}
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	for input, a2 := range map[string]string{"Germeny": "DE", "USSA": "US", "fraance": "FR", "reunon": "RE"} {
+		if suggestions := Suggest(input); len(suggestions) == 0 || suggestions[0].Alpha2 != a2 {
+			t.Fatalf("Suggest(%q) returned %v, expected %s first", input, suggestions, a2)
+		}
+	}
+
+	if suggestions := Suggest("UKK"); len(suggestions) == 0 || len(suggestions) > 5 {
+		t.Fatalf("Suggest(\"UKK\") returned %d suggestions", len(suggestions))
+	}
+
+	if len(Suggest("Xyzzyplugh")) != 0 || len(Suggest(" ")) != 0 {
+		t.Fatalf("Suggest returned suggestions for hopeless input")
+	}
+}
+
+func TestDrivingSide(t *testing.T) {
+	for a2, left := range map[string]bool{"GB": true, "US": false, "JP": true, "VI": true, "DE": false, "UK": false} {
+		if code, _ := GetByAlpha2(a2); code.DrivesOnLeft != left {
+			t.Fatalf("Expected DrivesOnLeft %v for %s", left, a2)
+		}
+	}
+
+	left, right := AllByDrivingSide(true), AllByDrivingSide(false)
+
+	if len(left) != len(drives_on_left) || len(left)+len(right) != 248 {
+		t.Fatalf("Unexpected driving side counts: %d left, %d right", len(left), len(right))
+	}
+
+	for _, cc := range append(left, right...) {
+		if cc.Alpha2 == "AQ" {
+			t.Fatalf("AllByDrivingSide returned Antarctica")
+		}
+	}
+}
+
+func TestHasAlpha3(t *testing.T) {
+	for a2, has := range map[string]bool{"DE": true, "EU": false, "UK": false, "YU": false} {
+		if code, _ := GetByAlpha2(a2); code.HasAlpha3() != has {
+			t.Fatalf("Expected HasAlpha3 %v for %s", has, a2)
+		}
+	}
+}
+
+func TestAssignmentProto(t *testing.T) {
+	if OFFICIALLY_ASSIGNED.Proto() != 1 || NOT_USED.Proto() != 6 || Assignment(42).Proto() != 0 {
+		t.Fatalf("Unexpected proto values")
+	}
+
+	for a := range assignment_names {
+		if got, err := AssignmentFromProto(a.Proto()); err != nil || got != a {
+			t.Fatalf("%v did not round-trip through Proto", a)
+		}
+	}
+
+	if _, err := AssignmentFromProto(0); err == nil {
+		t.Fatalf("AssignmentFromProto accepted UNSPECIFIED")
+	}
+}
+
+func TestDialingCodes(t *testing.T) {
+	codes := DialingCodes()
+
+	names := func(code string) string {
+		var n []string
+		for _, cc := range codes[code] {
+			n = append(n, cc.Alpha2)
+		}
+		return strings.Join(n, ",")
+	}
+
+	for code, members := range codes {
+		got, _ := GetByDialingCode(code)
+		if len(got) != len(members) {
+			t.Fatalf("DialingCodes()[%q] has %d entries, GetByDialingCode %d", code, len(members), len(got))
+		}
+	}
+
+	if got, _ := GetByDialingCode("+1"); len(codes["+1"]) != len(got) || len(got) < 20 {
+		t.Fatalf("Unexpected entries for +1: %s", names("+1"))
+	}
+
+	if names("+44") != "GG,IM,JE,GB,UK" || names("+228") != "TG" {
+		t.Fatalf("Unexpected entries for +44: %s", names("+44"))
+	}
+
+	if _, ok := codes["+1-268"]; ok {
+		t.Fatalf("Area code kept as a separate key in DialingCodes")
+	}
+
+	if _, ok := codes["228"]; ok {
+		t.Fatalf("Calling code without + in DialingCodes")
+	}
+}
+
+func TestValidateAlpha2(t *testing.T) {
+	for _, s := range []string{"DE", "de", "Us"} {
+		if err := ValidateAlpha2(s); err != nil {
+			t.Fatalf("ValidateAlpha2(%q) failed: %v", s, err)
+		}
+	}
+
+	for _, s := range []string{"", "D", "DEU", "D1", " DE", "\u00C4E"} {
+		if err := ValidateAlpha2(s); !errors.Is(err, ErrMalformedAlpha2) {
+			t.Fatalf("ValidateAlpha2(%q) returned %v, expected ErrMalformedAlpha2", s, err)
+		}
+	}
+
+	if err := ValidateAlpha2("QQ"); !errors.Is(err, ErrNotFound) || errors.Is(err, ErrMalformedAlpha2) {
+		t.Fatalf("ValidateAlpha2(\"QQ\") returned %v, expected ErrNotFound", err)
+	}
+}
+
+func TestRandomOfficial(t *testing.T) {
+	first := RandomOfficial(rand.New(rand.NewSource(42)))
+	second := RandomOfficial(rand.New(rand.NewSource(42)))
+
+	if !first.Equal(second) {
+		t.Fatalf("Same seed gave %s and %s", first.Alpha2, second.Alpha2)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		if code := RandomOfficial(r); !code.IsOfficiallyAssigned() {
+			t.Fatalf("RandomOfficial returned %s", code.Alpha2)
+		}
+	}
+}
+
+func TestNeighborDistance(t *testing.T) {
+	if !AreNeighbors("FR", "DE") || !AreNeighbors("de", "fr") || AreNeighbors("FR", "PL") {
+		t.Fatalf("AreNeighbors failed")
+	}
+
+	expected := []struct {
+		a, b     string
+		distance int
+	}{
+		{"DE", "DE", 0},
+		{"FR", "DE", 1},
+		{"PT", "RU", 5},
+		{"pt", "cn", 6},
+		{"GB", "FR", -1},
+		{"JP", "KR", -1},
+		{"DE", "QQ", -1},
+	}
+
+	for _, test := range expected {
+		if got := NeighborDistance(test.a, test.b); got != test.distance {
+			t.Fatalf("NeighborDistance(%s, %s) returned %d, expected %d", test.a, test.b, got, test.distance)
+		}
+	}
+}
+
+func TestBinary(t *testing.T) {
+	de, _ := GetByAlpha2("DE")
+
+	data, err := de.MarshalBinary()
+	if err != nil || string(data) != "\x01DE" {
+		t.Fatalf("Unexpected binary encoding %q, %v", data, err)
+	}
+
+	var decoded CountryCode
+	if err := decoded.UnmarshalBinary(data); err != nil || decoded.Name != "Germany" {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+
+	zero, _ := CountryCode{}.MarshalBinary()
+	if err := decoded.UnmarshalBinary(zero); err != nil || !decoded.IsZero() {
+		t.Fatalf("Zero CountryCode did not round-trip")
+	}
+
+	if err := decoded.UnmarshalBinary([]byte("\x01QQ")); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound for an unknown code, got %v", err)
+	}
+
+	for _, bad := range []string{"", "\x02DE", "\x01DEU"} {
+		if err := decoded.UnmarshalBinary([]byte(bad)); err == nil {
+			t.Fatalf("UnmarshalBinary accepted %q", bad)
+		}
+	}
+}
+
+func TestFindByAlpha2Prefix(t *testing.T) {
+	matches := FindByAlpha2Prefix("g")
+
+	if len(matches) == 0 || matches[0].Alpha2 != "GA" || matches[1].Alpha2 != "GB" {
+		t.Fatalf("Unexpected matches for \"g\": %v", matches)
+	}
+
+	for _, cc := range matches {
+		if cc.Alpha2[0] != 'G' {
+			t.Fatalf("FindByAlpha2Prefix(\"g\") returned %s", cc.Alpha2)
+		}
+	}
+
+	if matches := FindByAlpha2Prefix("DE"); len(matches) != 1 || matches[0].Alpha2 != "DE" {
+		t.Fatalf("FindByAlpha2Prefix(\"DE\") failed")
+	}
+
+	if len(FindByAlpha2Prefix("")) != 0 || len(FindByAlpha2Prefix("DEU")) != 0 {
+		t.Fatalf("FindByAlpha2Prefix matched a blank or over-long prefix")
+	}
+}
+
+func TestMacaoMonacoAlpha3(t *testing.T) {
+	if code, ok := GetByAlpha3("MAC"); !ok || code.Alpha2 != "MO" || code.Numeric != 446 {
+		t.Fatalf("GetByAlpha3(\"MAC\") returned %s, expected MO", code.Alpha2)
+	}
+
+	if code, ok := GetByAlpha3("MCO"); !ok || code.Alpha2 != "MC" || code.Numeric != 492 {
+		t.Fatalf("GetByAlpha3(\"MCO\") returned %s, expected MC", code.Alpha2)
+	}
+}
+
+func TestFindByNameFiltered(t *testing.T) {
+	if matches := FindByNameFiltered("Yug"); len(matches) != 0 {
+		t.Fatalf("Default filter returned %v", matches)
+	}
+
+	if matches := FindByNameFiltered("Yug", TRANSITIONALLY_RESERVED); len(matches) != 1 || matches[0].Alpha2 != "YU" {
+		t.Fatalf("Expected Yugoslavia with TRANSITIONALLY_RESERVED, got %v", matches)
+	}
+
+	if matches := FindByNameFiltered("Germ"); len(matches) != 1 || matches[0].Alpha2 != "DE" {
+		t.Fatalf("Default filter dropped Germany")
+	}
+}
+
+func TestAssignmentOf(t *testing.T) {
+	if a, ok := AssignmentOf("SU"); !ok || a != EXCEPTIONALLY_RESERVED {
+		t.Fatalf("AssignmentOf(\"SU\") returned %v, %v", a, ok)
+	}
+
+	if a, ok := AssignmentOf("de"); !ok || a != OFFICIALLY_ASSIGNED {
+		t.Fatalf("AssignmentOf(\"de\") returned %v, %v", a, ok)
+	}
+
+	if _, ok := AssignmentOf("ZZ"); ok {
+		t.Fatalf("AssignmentOf(\"ZZ\") found an entry")
+	}
+}
+
+func TestCentroids(t *testing.T) {
+	for _, cc := range All() {
+		expected := cc.IsOfficiallyAssigned() && cc.Alpha2 != "UM"
+		if cc.HasCoordinates() != expected {
+			t.Fatalf("Unexpected coordinates for %s", cc.Alpha2)
+		}
+	}
+
+	expected := []struct {
+		lat, lng float64
+		a2       string
+	}{
+		{52.52, 13.40, "DE"},
+		{47.08, 2.40, "FR"},
+		{-23.70, 133.88, "AU"},
+		{39.10, -94.58, "US"},
+		// Coastal cities can be nearer an island's centroid than their
+		// own country's; see Nearest.
+		{-33.87, 151.21, "NF"},
+		{40.71, -74.01, "BM"},
+	}
+
+	for _, test := range expected {
+		if code := Nearest(test.lat, test.lng); code.Alpha2 != test.a2 {
+			t.Fatalf("Nearest(%v, %v) returned %s, expected %s", test.lat, test.lng, code.Alpha2, test.a2)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	expected := []struct {
+		in, out  string
+		remapped bool
+	}{
+		{"UK", "GB", true},
+		{"uk", "GB", true},
+		{"EL", "GR", true},
+		{"GB", "GB", false},
+		{" de ", "DE", false},
+	}
+
+	for _, test := range expected {
+		if out, remapped := Normalize(test.in); out != test.out || remapped != test.remapped {
+			t.Fatalf("Normalize(%q) returned %q, %v", test.in, out, remapped)
+		}
+	}
+}
+
+// official_alpha2_reference is every officially assigned ISO 3166-1 alpha-2
+// code, 249 in all. Update it when ISO adds or withdraws a code.
+var official_alpha2_reference = []string{
+	"AD", "AE", "AF", "AG", "AI", "AL", "AM", "AO", "AQ", "AR", "AS", "AT", "AU", "AW", "AX", "AZ",
+	"BA", "BB", "BD", "BE", "BF", "BG", "BH", "BI", "BJ", "BL", "BM", "BN", "BO", "BQ", "BR", "BS",
+	"BT", "BV", "BW", "BY", "BZ", "CA", "CC", "CD", "CF", "CG", "CH", "CI", "CK", "CL", "CM", "CN",
+	"CO", "CR", "CU", "CV", "CW", "CX", "CY", "CZ", "DE", "DJ", "DK", "DM", "DO", "DZ", "EC", "EE",
+	"EG", "EH", "ER", "ES", "ET", "FI", "FJ", "FK", "FM", "FO", "FR", "GA", "GB", "GD", "GE", "GF",
+	"GG", "GH", "GI", "GL", "GM", "GN", "GP", "GQ", "GR", "GS", "GT", "GU", "GW", "GY", "HK", "HM",
+	"HN", "HR", "HT", "HU", "ID", "IE", "IL", "IM", "IN", "IO", "IQ", "IR", "IS", "IT", "JE", "JM",
+	"JO", "JP", "KE", "KG", "KH", "KI", "KM", "KN", "KP", "KR", "KW", "KY", "KZ", "LA", "LB", "LC",
+	"LI", "LK", "LR", "LS", "LT", "LU", "LV", "LY", "MA", "MC", "MD", "ME", "MF", "MG", "MH", "MK",
+	"ML", "MM", "MN", "MO", "MP", "MQ", "MR", "MS", "MT", "MU", "MV", "MW", "MX", "MY", "MZ", "NA",
+	"NC", "NE", "NF", "NG", "NI", "NL", "NO", "NP", "NR", "NU", "NZ", "OM", "PA", "PE", "PF", "PG",
+	"PH", "PK", "PL", "PM", "PN", "PR", "PS", "PT", "PW", "PY", "QA", "RE", "RO", "RS", "RU", "RW",
+	"SA", "SB", "SC", "SD", "SE", "SG", "SH", "SI", "SJ", "SK", "SL", "SM", "SN", "SO", "SR", "SS",
+	"ST", "SV", "SX", "SY", "SZ", "TC", "TD", "TF", "TG", "TH", "TJ", "TK", "TL", "TM", "TN", "TO",
+	"TR", "TT", "TV", "TW", "TZ", "UA", "UG", "UM", "US", "UY", "UZ", "VA", "VC", "VE", "VG", "VI",
+	"VN", "VU", "WF", "WS", "YE", "YT", "ZA", "ZM", "ZW",
+}
+
+func TestOfficialCodesComplete(t *testing.T) {
+	expected := make(map[string]bool)
+	for _, a2 := range official_alpha2_reference {
+		expected[a2] = true
+	}
+
+	var missing, extra []string
+
+	for _, a2 := range official_alpha2_reference {
+		if code, ok := GetByAlpha2(a2); !ok || !code.IsOfficiallyAssigned() {
+			missing = append(missing, a2)
+		}
+	}
+
+	for _, cc := range All() {
+		if cc.IsOfficiallyAssigned() && !expected[cc.Alpha2] {
+			extra = append(extra, cc.Alpha2)
+		}
+	}
+
+	if len(missing) > 0 || len(extra) > 0 {
+		t.Fatalf("Officially assigned codes differ from the reference: missing %v, extra %v", missing, extra)
+	}
+}
+
+func TestGetByAlpha3CaseInsensitive(t *testing.T) {
+	for _, a3 := range []string{"DEU", "deu", "Deu", " DEU "} {
+		if code, ok := GetByAlpha3(a3); !ok || code.Alpha2 != "DE" {
+			t.Fatalf("GetByAlpha3(%q) returned %s, expected DE", a3, code.Alpha2)
+		}
+	}
+
+	if _, ok := GetByAlpha3("qqq"); ok {
+		t.Fatalf("GetByAlpha3 found qqq")
+	}
+}
+
+func TestFlagOrCode(t *testing.T) {
+	us, _ := GetByAlpha2("US")
+	eu, _ := GetByAlpha2("EU")
+	yu, _ := GetByAlpha2("YU")
+
+	if us.FlagOrCode() != "\U0001F1FA\U0001F1F8" {
+		t.Fatalf("Unexpected FlagOrCode for US: %q", us.FlagOrCode())
+	}
+
+	if eu.FlagOrCode() != "[EU]" || yu.FlagOrCode() != "[YU]" {
+		t.Fatalf("Unexpected FlagOrCode fallback: %q, %q", eu.FlagOrCode(), yu.FlagOrCode())
+	}
+
+	if (CountryCode{}).FlagOrCode() != "" {
+		t.Fatalf("Zero CountryCode has a FlagOrCode")
+	}
+}
+
+func TestAllExcludingReserved(t *testing.T) {
+	codes := AllExcludingReserved()
+
+	if len(codes) != 250 {
+		t.Fatalf("Expected 250 entries, got %d", len(codes))
+	}
+
+	for i, cc := range codes {
+		if cc.IsReserved() {
+			t.Fatalf("Reserved entry %s included", cc.Alpha2)
+		}
+
+		if i > 0 && codes[i-1].Name > cc.Name {
+			t.Fatalf("Entries not sorted by name at %s", cc.Alpha2)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	de, _ := GetByAlpha2("DE")
+
+	if diff := Diff(de, de); len(diff) != 0 {
+		t.Fatalf("Diff of an entry with itself returned %v", diff)
+	}
+
+	patched := de
+	patched.DialingCode = "+4900"
+
+	diff := Diff(de, patched)
+	if len(diff) != 1 || diff["DialingCode"] != [2]interface{}{"+49", "+4900"} {
+		t.Fatalf("Unexpected diff %v", diff)
+	}
+
+	patched.Latitude = 0
+	if diff := Diff(de, patched); len(diff) != 2 {
+		t.Fatalf("Second field change not reported: %v", diff)
+	}
+}
+
+func TestTLDExceptions(t *testing.T) {
+	if code, ok := GetByTLD(".uk"); !ok || code.Alpha2 != "GB" || !code.IsOfficiallyAssigned() {
+		t.Fatalf("GetByTLD(\".uk\") returned %s, expected GB", code.Alpha2)
+	}
+
+	if code, ok := GetByTLD(".eu"); !ok || code.Alpha2 != "EU" {
+		t.Fatalf("GetByTLD(\".eu\") returned %s, expected EU", code.Alpha2)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `KR,KOR,410,"Korea, Republic of",+82,OFFICIALLY_ASSIGNED`) {
+		t.Fatalf("Names with commas not quoted")
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("WriteCSV produced invalid CSV: %v", err)
+	}
+
+	if len(records) != Count()+1 || strings.Join(records[0], ",") != "alpha2,alpha3,numeric,name,dialing_code,assignment" {
+		t.Fatalf("Unexpected CSV layout")
+	}
+
+	if records[1][0] != "AC" || records[len(records)-1][0] != "ZW" {
+		t.Fatalf("CSV rows not sorted by alpha-2")
+	}
+}
+
+func TestLoadOverrides(t *testing.T) {
+	de, _ := GetByAlpha2("DE")
+	defer func() {
+		by_alpha2["DE"] = de
+		buildIndexes()
+	}()
+
+	err := LoadOverrides(strings.NewReader("alpha2,dialing_code,name\nDE,+4999,Deutschland\n"))
+	if err != nil {
+		t.Fatalf("LoadOverrides failed: %v", err)
+	}
+
+	patched, _ := GetByAlpha2("DE")
+	if diff := Diff(de, patched); len(diff) != 2 || patched.DialingCode != "+4999" {
+		t.Fatalf("Unexpected changes after override: %v", diff)
+	}
+
+	if code, ok := GetByName("Deutschland"); !ok || code.Alpha2 != "DE" {
+		t.Fatalf("Overridden name not indexed")
+	}
+
+	if _, ok := GetByName("Germany"); ok {
+		t.Fatalf("Old name still indexed")
+	}
+
+	if matches := FindByNameLimit("Deutsch", -1); len(matches) != 1 || matches[0].Alpha2 != "DE" {
+		t.Fatalf("Overridden name not in name_keys: %v", matches)
+	}
+
+	if len(FindByNameLimit("Germany", -1)) != 0 {
+		t.Fatalf("Old name still in name_keys")
+	}
+
+	err = LoadOverrides(strings.NewReader("alpha2,alpha3,numeric\nFR,,\nQQ,,\nIT,DEU,\nES,,abc\n"))
+	if err == nil {
+		t.Fatalf("LoadOverrides accepted bad rows")
+	}
+
+	for _, line := range []string{"line 3", "line 4", "line 5"} {
+		if !strings.Contains(err.Error(), line) {
+			t.Fatalf("Error does not mention %s: %v", line, err)
+		}
+	}
+
+	if strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("Error mentions the valid row: %v", err)
+	}
+
+	if err := LoadOverrides(strings.NewReader("code,name\nDE,Germany\n")); err == nil {
+		t.Fatalf("LoadOverrides accepted an unknown column")
+	}
+
+	fr, _ := GetByAlpha2("FR")
+	batches := map[string]string{
+		"alpha2,alpha3\nFR,XYZ\nIT,XYZ\n":  "line 3: alpha-3 XYZ is also given to FR",
+		"alpha2,numeric\nFR,901\nIT,901\n": "line 3: numeric 901 is also given to FR",
+		"alpha2,alpha3\nFR,e1\n":           "line 2: malformed alpha-3",
+		"alpha2,numeric\nFR,1000\n":        "line 2: malformed numeric",
+	}
+
+	for batch, want := range batches {
+		if err := LoadOverrides(strings.NewReader(batch)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("LoadOverrides(%q) returned %v, expected %s", batch, err, want)
+		}
+
+		if code, ok := GetByAlpha3("FRA"); !ok || code.Alpha2 != "FR" || len(Diff(fr, code)) != 0 {
+			t.Fatalf("LoadOverrides(%q) patched FR despite failing", batch)
+		}
+	}
+
+	if err := LoadOverrides(strings.NewReader("alpha2,numeric\nMM,104\nBU,104\n")); err != nil {
+		t.Fatalf("LoadOverrides rejected rows keeping a shared numeric code: %v", err)
+	}
+}
+
+func TestSameTravelArea(t *testing.T) {
+	cases := []struct {
+		a, b, area string
+		want       bool
+	}{
+		{"FR", "DE", "Schengen", true},
+		{"FR", "GB", "Schengen", false},
+		{"NO", "CH", "schengen", true},
+		{"IE", "FR", "Schengen", false},
+		{"GB", "IE", "CTA", true},
+		{"JE", "IM", "cta", true},
+		{"GB", "FR", "CTA", false},
+		{"FR", "DE", "Narnia", false},
+		{"fr", " de ", "schengen", true},
+		{"gb", "ie", "CTA", true},
+	}
+
+	for _, c := range cases {
+		if got := SameTravelArea(c.a, c.b, c.area); got != c.want {
+			t.Fatalf("SameTravelArea(%q, %q, %q) = %v, want %v", c.a, c.b, c.area, got, c.want)
+		}
+	}
+
+	saved := TravelAreas["Schengen"][0]
+	TravelAreas["Schengen"][0] = "ZZ"
+	if len(AllInGroup("Schengen")) == 0 || AllInGroup("Schengen")[0].Alpha2 != saved {
+		t.Fatalf("Amending TravelAreas changed the Schengen group")
+	}
+	TravelAreas["Schengen"][0] = saved
+
+	for area, members := range TravelAreas {
+		for _, a := range members {
+			if _, ok := GetByAlpha2(a); !ok {
+				t.Fatalf("%s member %s is not in the dataset", area, a)
+			}
+			for _, b := range members {
+				if !SameTravelArea(a, b, area) {
+					t.Fatalf("%s and %s should share %s", a, b, area)
+				}
+			}
+		}
+	}
+}
+
+func TestGetByAny(t *testing.T) {
+	cases := map[string]string{
+		"de":                       "DE",
+		" FR ":                     "FR",
+		"deu":                      "DE",
+		"GBR":                      "GB",
+		"840":                      "US",
+		"036":                      "AU",
+		"Germany":                  "DE",
+		"United States of America": "US",
+		"uk":                       "UK",
+		"Great Britain":            "GB",
+	}
+
+	for input, want := range cases {
+		code, ok := GetByAny(input)
+		if !ok || code.Alpha2 != want {
+			t.Fatalf("GetByAny(%q) = %s, %v, want %s", input, code.Alpha2, ok, want)
+		}
+	}
+
+	for _, input := range []string{"", "  ", "QQ", "000", "Atlantis"} {
+		if _, ok := GetByAny(input); ok {
+			t.Fatalf("GetByAny(%q) should fail", input)
+		}
+	}
+}
+
+var update = flag.Bool("update", false, "rewrite testdata/countries.json from the live dataset")
+
+// TestDatasetGolden compares the whole dataset against testdata/countries.json
+// so that unintended data changes fail. After an intended change, regenerate
+// the file with:
+//
+//	go test -run TestDatasetGolden -update
+func TestDatasetGolden(t *testing.T) {
+	data, err := MarshalDataset()
+	if err != nil {
+		t.Fatalf("MarshalDataset failed: %v", err)
+	}
+
+	var live bytes.Buffer
+	if err := json.Indent(&live, data, "", "  "); err != nil {
+		t.Fatalf("Indenting dataset failed: %v", err)
+	}
+	live.WriteByte('\n')
+
+	golden := filepath.Join("testdata", "countries.json")
+
+	if *update {
+		if err := ioutil.WriteFile(golden, live.Bytes(), 0644); err != nil {
+			t.Fatalf("Writing %s failed: %v", golden, err)
+		}
+	}
+
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("Reading %s failed: %v (run with -update to create it)", golden, err)
+	}
+
+	if !bytes.Equal(live.Bytes(), want) {
+		got := strings.Split(live.String(), "\n")
+		lines := strings.Split(string(want), "\n")
+		for i := 0; i < len(got) && i < len(lines); i++ {
+			if got[i] != lines[i] {
+				t.Fatalf("Dataset differs from %s at line %d:\n got: %s\nwant: %s\n(run with -update if the change is intended)", golden, i+1, got[i], lines[i])
+			}
+		}
+		t.Fatalf("Dataset differs in length from %s (run with -update if the change is intended)", golden)
+	}
+}
+
+func TestTimezones(t *testing.T) {
+	us, _ := GetByAlpha2("US")
+	if len(us.Timezones()) < 10 || us.Timezones()[0] != "America/New_York" {
+		t.Fatalf("Unexpected US time zones: %v", us.Timezones())
+	}
+
+	de, _ := GetByAlpha2("DE")
+	if len(de.Timezones()) == 0 || de.Timezones()[0] != "Europe/Berlin" {
+		t.Fatalf("Unexpected DE time zones: %v", de.Timezones())
+	}
+
+	fr, _ := GetByAlpha2("FR")
+	if len(fr.Timezones()) < 2 || fr.Timezones()[0] != "Europe/Paris" {
+		t.Fatalf("Unexpected FR time zones: %v", fr.Timezones())
+	}
+
+	for _, cc := range All() {
+		if len(cc.Timezones()) > 0 && !cc.IsOfficiallyAssigned() {
+			t.Fatalf("%s is not officially assigned but has time zones", cc.Alpha2)
+		}
+	}
+
+	if codes := AllByTimezone("Europe/Berlin"); len(codes) != 1 || codes[0].Alpha2 != "DE" {
+		t.Fatalf("Unexpected entries for Europe/Berlin: %v", codes)
+	}
+
+	if codes := AllByTimezone("Indian/Reunion"); len(codes) != 2 || codes[0].Alpha2 != "FR" || codes[1].Alpha2 != "RE" {
+		t.Fatalf("Unexpected entries for Indian/Reunion: %v", codes)
+	}
+
+	if len(AllByTimezone("Mars/Olympus_Mons")) != 0 {
+		t.Fatalf("AllByTimezone matched an unknown zone")
+	}
+}
+
+func TestFindOrSearch(t *testing.T) {
+	matches := FindOrSearch("korea")
+	if len(matches) != 2 {
+		t.Fatalf("Expected both Koreas, got %v", matches)
+	}
+
+	for _, a2 := range []string{"KP", "KR"} {
+		found := false
+		for _, cc := range matches {
+			found = found || cc.Alpha2 == a2
+		}
+		if !found {
+			t.Fatalf("FindOrSearch(\"korea\") is missing %s", a2)
+		}
+	}
+
+	if len(FindByName("islands")) != 0 {
+		t.Fatalf("Expected no prefix matches for \"islands\"")
+	}
+
+	if matches := FindOrSearch("islands"); len(matches) == 0 || len(matches) != len(SearchByName("islands")) {
+		t.Fatalf("FindOrSearch did not fall back to SearchByName: %v", matches)
+	}
+
+	if len(FindOrSearch(" ")) != 0 || len(FindOrSearch("Atlantis")) != 0 {
+		t.Fatalf("FindOrSearch matched hopeless input")
+	}
+}
+
+func TestRegionCounts(t *testing.T) {
+	counts := RegionCounts()
+
+	total := 0
+	for continent, n := range counts {
+		if continent == "" {
+			t.Fatalf("%d officially assigned entries have no continent", n)
+		}
+		total += n
+	}
+
+	if total != CountByAssignment()[OFFICIALLY_ASSIGNED] {
+		t.Fatalf("Region counts sum to %d, expected %d", total, CountByAssignment()[OFFICIALLY_ASSIGNED])
+	}
+
+	if counts["Europe"] != len(AllByContinent("Europe")) || len(counts) != 7 {
+		t.Fatalf("Unexpected region counts: %v", counts)
+	}
+}
+
+func TestCurrencySymbol(t *testing.T) {
+	dollar := make(map[string]bool)
+	for _, cc := range AllByCurrencySymbol("$") {
+		dollar[cc.Alpha2] = true
+	}
+
+	for _, a2 := range []string{"US", "CA", "AU", "NZ", "MX"} {
+		if !dollar[a2] {
+			t.Fatalf("AllByCurrencySymbol(\"$\") is missing %s", a2)
+		}
+	}
+
+	euro := AllByCurrencySymbol("\u20AC")
+	if len(euro) == 0 || len(euro) != len(AllByCurrency("EUR")) {
+		t.Fatalf("AllByCurrencySymbol(\"\\u20AC\") returned %d entries, expected the eurozone", len(euro))
+	}
+
+	for _, cc := range euro {
+		if cc.CurrencyCode != "EUR" {
+			t.Fatalf("%s uses %s, not the euro", cc.Alpha2, cc.CurrencyCode)
+		}
+	}
+
+	for currency := range currency_symbols {
+		if _, ok := currency_members[currency]; !ok {
+			t.Fatalf("Symbol listed for unused currency %s", currency)
+		}
+	}
+
+	if gb, _ := GetByAlpha2("GB"); gb.CurrencySymbol != "\u00A3" {
+		t.Fatalf("Unexpected symbol for GB: %q", gb.CurrencySymbol)
+	}
+
+	if len(AllByCurrencySymbol("")) != 0 || len(AllByCurrencySymbol("\u00A4")) != 0 {
+		t.Fatalf("AllByCurrencySymbol matched a blank or unused symbol")
+	}
+}
+
+func TestInfoPayload(t *testing.T) {
+	us, _ := GetByAlpha2("US")
+	info := us.InfoPayload()
+
+	if info.Flag != "\U0001F1FA\U0001F1F8" || info.Dialing == nil || info.Dialing.CountryCode != "+1" || info.CurrencyCode != "USD" {
+		t.Fatalf("Unexpected payload for US: %+v", info)
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatalf("Marshaling the US payload failed: %v", err)
+	}
+
+	for _, want := range []string{"\"flag\":\"\U0001F1FA\U0001F1F8\"", `"country_code":"+1"`, `"currency_code":"USD"`, `"assignment":"OFFICIALLY_ASSIGNED"`, `"region":"Americas"`} {
+		if !strings.Contains(string(data), want) {
+			t.Fatalf("US payload %s is missing %s", data, want)
+		}
+	}
+
+	eu, _ := GetByAlpha2("EU")
+	data, _ = json.Marshal(eu.InfoPayload())
+	for _, absent := range []string{`"numeric"`, `"currency_code"`, `"region"`} {
+		if strings.Contains(string(data), absent) {
+			t.Fatalf("EU payload %s should omit %s", data, absent)
+		}
+	}
+}
+
+func TestLookupByAlpha2Suggest(t *testing.T) {
+	if code, err := LookupByAlpha2Suggest("DE"); err != nil || code.Alpha2 != "DE" {
+		t.Fatalf("LookupByAlpha2Suggest(\"DE\") failed: %v", err)
+	}
+
+	suggestions := map[string]string{
+		"GRB": `did you mean "GB"?`,
+		"gbr": `did you mean "GB"?`,
+		"us":  `did you mean "US"?`,
+		"DEU": `did you mean "DE"?`,
+		"QQ":  `did you mean "AQ"?`,
+		"XX":  `did you mean "AX"?`,
+		"DW":  `did you mean "AW"?`,
+	}
+
+	for input, want := range suggestions {
+		_, err := LookupByAlpha2Suggest(input)
+		if !errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), want) {
+			t.Fatalf("LookupByAlpha2Suggest(%q) returned %v, expected it to contain %s", input, err, want)
+		}
+	}
+
+	if _, err := LookupByAlpha2Suggest("XYZZY"); !errors.Is(err, ErrNotFound) || strings.Contains(err.Error(), "did you mean") {
+		t.Fatalf("LookupByAlpha2Suggest(\"XYZZY\") suggested a code: %v", err)
+	}
+
+	if d := editDistance("GRB", "GBR"); d != 1 {
+		t.Fatalf("editDistance(\"GRB\", \"GBR\") = %d, expected 1", d)
+	}
+}
+
+func TestNameIndexPrefersOfficial(t *testing.T) {
+	defer buildIndexes()
+
+	for i := 0; i < 20; i++ {
+		buildIndexes()
+
+		if code, ok := GetByName("Finland"); !ok || code.Alpha2 != "FI" {
+			t.Fatalf("GetByName(\"Finland\") = %s, expected FI", code.Alpha2)
+		}
+
+		if code, ok := GetByAny("United Kingdom"); !ok || code.Alpha2 != "GB" {
+			t.Fatalf("GetByAny(\"United Kingdom\") = %s, expected GB", code.Alpha2)
+		}
+
+		if matches := FindByName("Finland"); len(matches) != 1 || matches[0].Alpha2 != "FI" {
+			t.Fatalf("FindByName(\"Finland\") = %v, expected FI", matches)
+		}
+	}
+}
+
+// findByNameLimitTrie is the trie-walking FindByNameLimit that name_keys
+// replaced, kept to check and benchmark against.
+func findByNameLimitTrie(prefix string, n int) []CountryCode {
+	matches := FindByNameSorted(prefix)
+
+	if n >= 0 && len(matches) > n {
+		matches = matches[:n]
+	}
+
+	return matches
+}
+
+func TestFindByNameLimitMatchesTrie(t *testing.T) {
+	prefixes := []string{"", " ", "united", "\u00C5land", "aland", "korea, r"}
+	for c := 'a'; c <= 'z'; c++ {
+		prefixes = append(prefixes, string(c), string(c)+"a", string(c)+"o")
+	}
+
+	for _, prefix := range prefixes {
+		for _, n := range []int{-1, 0, 3} {
+			got, want := FindByNameLimit(prefix, n), findByNameLimitTrie(prefix, n)
+			if len(got) != len(want) {
+				t.Fatalf("FindByNameLimit(%q, %d) returned %d entries, the trie %d", prefix, n, len(got), len(want))
+			}
+			for i := range got {
+				if !got[i].Equal(want[i]) {
+					t.Fatalf("FindByNameLimit(%q, %d)[%d] = %s, the trie gives %s", prefix, n, i, got[i].Alpha2, want[i].Alpha2)
+				}
+			}
+		}
+	}
+}
+
+var benchMatches []CountryCode
+
+// BenchmarkFindByNameLimit compares the sorted-slice range scan FindByNameLimit
+// uses with walking name_trie. On the machine it was chosen on:
+//
+//	Sorted/s        14623 ns/op   17 allocs/op    Trie/s        27284 ns/op   27 allocs/op
+//	Sorted/united    1876 ns/op    7 allocs/op    Trie/united    2604 ns/op   15 allocs/op
+//	Sorted/zimb       504 ns/op    3 allocs/op    Trie/zimb       958 ns/op   11 allocs/op
+func BenchmarkFindByNameLimit(b *testing.B) {
+	impls := []struct {
+		name string
+		find func(string, int) []CountryCode
+	}{
+		{"Sorted", FindByNameLimit},
+		{"Trie", findByNameLimitTrie},
+	}
+
+	for _, impl := range impls {
+		for _, prefix := range []string{"s", "united", "zimb"} {
+			b.Run(impl.name+"/"+prefix, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					benchMatches = impl.find(prefix, 5)
+				}
+			})
+		}
+	}
+}
+
+func TestFlagEmojiRoundTrip(t *testing.T) {
+	for _, cc := range All() {
+		if len(cc.Alpha2) != 2 {
+			continue
+		}
+
+		flag := cc.FlagEmoji()
+		if a2, ok := Alpha2ForFlagEmoji(flag); !ok || a2 != cc.Alpha2 {
+			t.Fatalf("Flag %q for %s decodes to %q, %v", flag, cc.Alpha2, a2, ok)
+		}
+	}
+
+	if a2, ok := Alpha2ForFlagEmoji("\U0001F1E6\U0001F1FF"); !ok || a2 != "AZ" {
+		t.Fatalf("Alpha2ForFlagEmoji failed at the ends of the range: %q", a2)
+	}
+
+	for _, flag := range []string{"", "US", "\U0001F1FA", "\U0001F1FA\U0001F1F8\U0001F1FA", "\U0001F1E5\U0001F1E6", "\U0001F200\U0001F1E6"} {
+		if _, ok := Alpha2ForFlagEmoji(flag); ok {
+			t.Fatalf("Alpha2ForFlagEmoji(%q) accepted invalid input", flag)
+		}
+	}
+}
+
+func TestCountryCodeComparable(t *testing.T) {
+	de, _ := GetByAlpha2("DE")
+	seen := map[CountryCode]bool{de: true}
+
+	if again, _ := GetByAlpha2("DE"); again != de || !seen[again] {
+		t.Fatalf("Equal entries did not compare equal")
+	}
+
+	de.Languages()[0] = "xx"
+	de.Borders()[0] = "XX"
+	de.Timezones()[0] = "Mars/Olympus_Mons"
+
+	if de.Languages()[0] != "de" || de.Borders()[0] != "AT" || de.Timezones()[0] != "Europe/Berlin" {
+		t.Fatalf("Modifying a returned slice changed the dataset")
+	}
+}
+
+func TestLoadOverridesKeepsShadowedEntries(t *testing.T) {
+	mm, _ := GetByAlpha2("MM")
+	fi, _ := GetByAlpha2("FI")
+	defer func() {
+		by_alpha2["MM"], by_alpha2["FI"] = mm, fi
+		buildIndexes()
+	}()
+
+	if err := LoadOverrides(strings.NewReader("alpha2,numeric,name\nMM,105,\nFI,,Suomi\n")); err != nil {
+		t.Fatalf("LoadOverrides failed: %v", err)
+	}
+
+	if code, ok := GetByNumeric(104); !ok || code.Alpha2 != "BU" {
+		t.Fatalf("GetByNumeric(104) = %s, %v, expected BU", code.Alpha2, ok)
+	}
+
+	if code, ok := GetByName("Finland"); !ok || code.Alpha2 != "SF" {
+		t.Fatalf("GetByName(\"Finland\") = %s, %v, expected SF", code.Alpha2, ok)
+	}
+
+	if matches := FindByName("Finl"); len(matches) != 1 || matches[0].Alpha2 != "SF" {
+		t.Fatalf("FindByName(\"Finl\") = %v, expected SF", matches)
+	}
+
+	if code, ok := GetByName("Suomi"); !ok || code.Alpha2 != "FI" {
+		t.Fatalf("GetByName(\"Suomi\") = %s, %v, expected FI", code.Alpha2, ok)
+	}
+}
+
+func TestWriteCSVLoadOverridesRoundTrip(t *testing.T) {
+	before, _ := MarshalDataset()
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+
+	if err := LoadOverrides(&buf); err != nil {
+		t.Fatalf("LoadOverrides could not read WriteCSV's output: %v", err)
+	}
+
+	if after, _ := MarshalDataset(); !bytes.Equal(before, after) {
+		t.Fatalf("Round trip through WriteCSV and LoadOverrides changed the dataset")
+	}
+
+	for numeric, a2 := range map[int]string{104: "MM", 246: "FI"} {
+		if code, _ := GetByNumeric(numeric); code.Alpha2 != a2 {
+			t.Fatalf("GetByNumeric(%d) = %s after the round trip, expected %s", numeric, code.Alpha2, a2)
+		}
+	}
+}
+
+func TestCapitalTimezoneCoverage(t *testing.T) {
+	omitted := map[string]bool{"AQ": true, "BV": true, "HM": true, "UM": true}
+
+	for _, cc := range All() {
+		if !cc.IsOfficiallyAssigned() {
+			continue
+		}
+
+		if _, ok := cc.CapitalTimezone(); ok == omitted[cc.Alpha2] {
+			t.Fatalf("CapitalTimezone for %s returned %v, contradicting capital_timezones' doc", cc.Alpha2, ok)
+		}
+	}
+}
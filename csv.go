@@ -0,0 +1,40 @@
+package countrycodes
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// WriteCSV writes the whole dataset to w as CSV, one row per entry sorted by
+// alpha-2, after a header row of
+// alpha2,alpha3,numeric,name,dialing_code,assignment. Assignment is written
+// as its constant name, and fields containing commas or quotes are quoted
+// as RFC 4180 requires.
+func WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"alpha2", "alpha3", "numeric", "name", "dialing_code", "assignment"}); err != nil {
+		return err
+	}
+
+	var err error
+	ForEach(func(cc CountryCode) bool {
+		err = cw.Write([]string{
+			cc.Alpha2,
+			cc.Alpha3,
+			strconv.Itoa(cc.Numeric),
+			cc.Name,
+			cc.DialingCode,
+			cc.Assignment.String(),
+		})
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
@@ -0,0 +1,479 @@
+package countrycodes
+
+import (
+	"strings"
+)
+
+// currency_members lists, by ISO 4217 code, the officially assigned entries
+// using each currency. Every entry has exactly one primary currency: the
+// currency it issues itself where it has one (BT uses BTN, not INR; PA uses
+// PAB, not USD), otherwise the foreign currency it has adopted (EC uses
+// USD). Antarctica has none.
+var currency_members = map[string][]string{
+	"AED": {"AE"},
+	"AFN": {"AF"},
+	"ALL": {"AL"},
+	"AMD": {"AM"},
+	"AOA": {"AO"},
+	"ARS": {"AR"},
+	"AUD": {"AU", "CC", "CX", "HM", "KI", "NF", "NR", "TV"},
+	"AWG": {"AW"},
+	"AZN": {"AZ"},
+	"BAM": {"BA"},
+	"BBD": {"BB"},
+	"BDT": {"BD"},
+	"BHD": {"BH"},
+	"BIF": {"BI"},
+	"BMD": {"BM"},
+	"BND": {"BN"},
+	"BOB": {"BO"},
+	"BRL": {"BR"},
+	"BSD": {"BS"},
+	"BTN": {"BT"},
+	"BWP": {"BW"},
+	"BYN": {"BY"},
+	"BZD": {"BZ"},
+	"CAD": {"CA"},
+	"CDF": {"CD"},
+	"CHF": {"CH", "LI"},
+	"CLP": {"CL"},
+	"CNY": {"CN"},
+	"COP": {"CO"},
+	"CRC": {"CR"},
+	"CUP": {"CU"},
+	"CVE": {"CV"},
+	"CZK": {"CZ"},
+	"DJF": {"DJ"},
+	"DKK": {"DK", "FO", "GL"},
+	"DOP": {"DO"},
+	"DZD": {"DZ"},
+	"EGP": {"EG"},
+	"ERN": {"ER"},
+	"ETB": {"ET"},
+	"EUR": {
+		"AD", "AT", "AX", "BE", "BG", "BL", "CY", "DE", "EE", "ES", "FI", "FR",
+		"GF", "GP", "GR", "HR", "IE", "IT", "LT", "LU", "LV", "MC", "ME", "MF",
+		"MQ", "MT", "NL", "PM", "PT", "RE", "SI", "SK", "SM", "TF", "VA", "YT",
+	},
+	"FJD": {"FJ"},
+	"FKP": {"FK"},
+	"GBP": {"GB", "GG", "GS", "IM", "JE"},
+	"GEL": {"GE"},
+	"GHS": {"GH"},
+	"GIP": {"GI"},
+	"GMD": {"GM"},
+	"GNF": {"GN"},
+	"GTQ": {"GT"},
+	"GYD": {"GY"},
+	"HKD": {"HK"},
+	"HNL": {"HN"},
+	"HTG": {"HT"},
+	"HUF": {"HU"},
+	"IDR": {"ID"},
+	"ILS": {"IL", "PS"},
+	"INR": {"IN"},
+	"IQD": {"IQ"},
+	"IRR": {"IR"},
+	"ISK": {"IS"},
+	"JMD": {"JM"},
+	"JOD": {"JO"},
+	"JPY": {"JP"},
+	"KES": {"KE"},
+	"KGS": {"KG"},
+	"KHR": {"KH"},
+	"KMF": {"KM"},
+	"KPW": {"KP"},
+	"KRW": {"KR"},
+	"KWD": {"KW"},
+	"KYD": {"KY"},
+	"KZT": {"KZ"},
+	"LAK": {"LA"},
+	"LBP": {"LB"},
+	"LKR": {"LK"},
+	"LRD": {"LR"},
+	"LSL": {"LS"},
+	"LYD": {"LY"},
+	"MAD": {"EH", "MA"},
+	"MDL": {"MD"},
+	"MGA": {"MG"},
+	"MKD": {"MK"},
+	"MMK": {"MM"},
+	"MNT": {"MN"},
+	"MOP": {"MO"},
+	"MRU": {"MR"},
+	"MUR": {"MU"},
+	"MVR": {"MV"},
+	"MWK": {"MW"},
+	"MXN": {"MX"},
+	"MYR": {"MY"},
+	"MZN": {"MZ"},
+	"NAD": {"NA"},
+	"NGN": {"NG"},
+	"NIO": {"NI"},
+	"NOK": {"BV", "NO", "SJ"},
+	"NPR": {"NP"},
+	"NZD": {"CK", "NU", "NZ", "PN", "TK"},
+	"OMR": {"OM"},
+	"PAB": {"PA"},
+	"PEN": {"PE"},
+	"PGK": {"PG"},
+	"PHP": {"PH"},
+	"PKR": {"PK"},
+	"PLN": {"PL"},
+	"PYG": {"PY"},
+	"QAR": {"QA"},
+	"RON": {"RO"},
+	"RSD": {"RS"},
+	"RUB": {"RU"},
+	"RWF": {"RW"},
+	"SAR": {"SA"},
+	"SBD": {"SB"},
+	"SCR": {"SC"},
+	"SDG": {"SD"},
+	"SEK": {"SE"},
+	"SGD": {"SG"},
+	"SHP": {"SH"},
+	"SLE": {"SL"},
+	"SOS": {"SO"},
+	"SRD": {"SR"},
+	"SSP": {"SS"},
+	"STN": {"ST"},
+	"SYP": {"SY"},
+	"SZL": {"SZ"},
+	"THB": {"TH"},
+	"TJS": {"TJ"},
+	"TMT": {"TM"},
+	"TND": {"TN"},
+	"TOP": {"TO"},
+	"TRY": {"TR"},
+	"TTD": {"TT"},
+	"TWD": {"TW"},
+	"TZS": {"TZ"},
+	"UAH": {"UA"},
+	"UGX": {"UG"},
+	"USD": {
+		"AS", "BQ", "EC", "FM", "GU", "IO", "MH", "MP", "PR", "PW", "SV", "TC",
+		"TL", "UM", "US", "VG", "VI",
+	},
+	"UYU": {"UY"},
+	"UZS": {"UZ"},
+	"VES": {"VE"},
+	"VND": {"VN"},
+	"VUV": {"VU"},
+	"WST": {"WS"},
+	"XAF": {"CF", "CG", "CM", "GA", "GQ", "TD"},
+	"XCD": {"AG", "AI", "DM", "GD", "KN", "LC", "MS", "VC"},
+	"XCG": {"CW", "SX"},
+	"XOF": {"BF", "BJ", "CI", "GW", "ML", "NE", "SN", "TG"},
+	"XPF": {"NC", "PF", "WF"},
+	"YER": {"YE"},
+	"ZAR": {"ZA"},
+	"ZMW": {"ZM"},
+	"ZWG": {"ZW"},
+}
+
+// currency_numerics holds the ISO 4217 numeric code of each currency in
+// currency_members.
+var currency_numerics = map[string]int{
+	"AED": 784,
+	"AFN": 971,
+	"ALL": 8,
+	"AMD": 51,
+	"AOA": 973,
+	"ARS": 32,
+	"AUD": 36,
+	"AWG": 533,
+	"AZN": 944,
+	"BAM": 977,
+	"BBD": 52,
+	"BDT": 50,
+	"BHD": 48,
+	"BIF": 108,
+	"BMD": 60,
+	"BND": 96,
+	"BOB": 68,
+	"BRL": 986,
+	"BSD": 44,
+	"BTN": 64,
+	"BWP": 72,
+	"BYN": 933,
+	"BZD": 84,
+	"CAD": 124,
+	"CDF": 976,
+	"CHF": 756,
+	"CLP": 152,
+	"CNY": 156,
+	"COP": 170,
+	"CRC": 188,
+	"CUP": 192,
+	"CVE": 132,
+	"CZK": 203,
+	"DJF": 262,
+	"DKK": 208,
+	"DOP": 214,
+	"DZD": 12,
+	"EGP": 818,
+	"ERN": 232,
+	"ETB": 230,
+	"EUR": 978,
+	"FJD": 242,
+	"FKP": 238,
+	"GBP": 826,
+	"GEL": 981,
+	"GHS": 936,
+	"GIP": 292,
+	"GMD": 270,
+	"GNF": 324,
+	"GTQ": 320,
+	"GYD": 328,
+	"HKD": 344,
+	"HNL": 340,
+	"HTG": 332,
+	"HUF": 348,
+	"IDR": 360,
+	"ILS": 376,
+	"INR": 356,
+	"IQD": 368,
+	"IRR": 364,
+	"ISK": 352,
+	"JMD": 388,
+	"JOD": 400,
+	"JPY": 392,
+	"KES": 404,
+	"KGS": 417,
+	"KHR": 116,
+	"KMF": 174,
+	"KPW": 408,
+	"KRW": 410,
+	"KWD": 414,
+	"KYD": 136,
+	"KZT": 398,
+	"LAK": 418,
+	"LBP": 422,
+	"LKR": 144,
+	"LRD": 430,
+	"LSL": 426,
+	"LYD": 434,
+	"MAD": 504,
+	"MDL": 498,
+	"MGA": 969,
+	"MKD": 807,
+	"MMK": 104,
+	"MNT": 496,
+	"MOP": 446,
+	"MRU": 929,
+	"MUR": 480,
+	"MVR": 462,
+	"MWK": 454,
+	"MXN": 484,
+	"MYR": 458,
+	"MZN": 943,
+	"NAD": 516,
+	"NGN": 566,
+	"NIO": 558,
+	"NOK": 578,
+	"NPR": 524,
+	"NZD": 554,
+	"OMR": 512,
+	"PAB": 590,
+	"PEN": 604,
+	"PGK": 598,
+	"PHP": 608,
+	"PKR": 586,
+	"PLN": 985,
+	"PYG": 600,
+	"QAR": 634,
+	"RON": 946,
+	"RSD": 941,
+	"RUB": 643,
+	"RWF": 646,
+	"SAR": 682,
+	"SBD": 90,
+	"SCR": 690,
+	"SDG": 938,
+	"SEK": 752,
+	"SGD": 702,
+	"SHP": 654,
+	"SLE": 925,
+	"SOS": 706,
+	"SRD": 968,
+	"SSP": 728,
+	"STN": 930,
+	"SYP": 760,
+	"SZL": 748,
+	"THB": 764,
+	"TJS": 972,
+	"TMT": 934,
+	"TND": 788,
+	"TOP": 776,
+	"TRY": 949,
+	"TTD": 780,
+	"TWD": 901,
+	"TZS": 834,
+	"UAH": 980,
+	"UGX": 800,
+	"USD": 840,
+	"UYU": 858,
+	"UZS": 860,
+	"VES": 928,
+	"VND": 704,
+	"VUV": 548,
+	"WST": 882,
+	"XAF": 950,
+	"XCD": 951,
+	"XCG": 532,
+	"XOF": 952,
+	"XPF": 953,
+	"YER": 886,
+	"ZAR": 710,
+	"ZMW": 967,
+	"ZWG": 924,
+}
+
+// currency_symbols holds the symbol used locally for each currency in
+// currency_members that has one in common use, following the Unicode CLDR
+// narrow symbols, so the dollars all share "$". Currencies usually written
+// as their ISO 4217 code, such as CHF, are left out.
+var currency_symbols = map[string]string{
+	"AMD": "\u058F",
+	"ARS": "$",
+	"AUD": "$",
+	"AZN": "\u20BC",
+	"BAM": "KM",
+	"BBD": "$",
+	"BDT": "\u09F3",
+	"BMD": "$",
+	"BND": "$",
+	"BOB": "Bs",
+	"BRL": "R$",
+	"BSD": "$",
+	"BWP": "P",
+	"BZD": "$",
+	"CAD": "$",
+	"CLP": "$",
+	"CNY": "\u00A5",
+	"COP": "$",
+	"CRC": "\u20A1",
+	"CUP": "$",
+	"CZK": "K\u010D",
+	"DKK": "kr",
+	"DOP": "$",
+	"EGP": "\u00A3",
+	"EUR": "\u20AC",
+	"FJD": "$",
+	"FKP": "\u00A3",
+	"GBP": "\u00A3",
+	"GEL": "\u20BE",
+	"GIP": "\u00A3",
+	"GNF": "FG",
+	"GTQ": "Q",
+	"GYD": "$",
+	"HKD": "$",
+	"HNL": "L",
+	"HUF": "Ft",
+	"IDR": "Rp",
+	"ILS": "\u20AA",
+	"INR": "\u20B9",
+	"ISK": "kr",
+	"JMD": "$",
+	"JPY": "\u00A5",
+	"KHR": "\u17DB",
+	"KMF": "CF",
+	"KPW": "\u20A9",
+	"KRW": "\u20A9",
+	"KYD": "$",
+	"KZT": "\u20B8",
+	"LAK": "\u20AD",
+	"LKR": "Rs",
+	"LRD": "$",
+	"MGA": "Ar",
+	"MNT": "\u20AE",
+	"MUR": "Rs",
+	"MXN": "$",
+	"MYR": "RM",
+	"NAD": "$",
+	"NGN": "\u20A6",
+	"NIO": "C$",
+	"NOK": "kr",
+	"NPR": "Rs",
+	"NZD": "$",
+	"PHP": "\u20B1",
+	"PKR": "Rs",
+	"PLN": "z\u0142",
+	"PYG": "\u20B2",
+	"RON": "lei",
+	"RUB": "\u20BD",
+	"RWF": "RF",
+	"SBD": "$",
+	"SEK": "kr",
+	"SGD": "$",
+	"SHP": "\u00A3",
+	"SRD": "$",
+	"SSP": "\u00A3",
+	"SYP": "\u00A3",
+	"THB": "\u0E3F",
+	"TOP": "T$",
+	"TRY": "\u20BA",
+	"TTD": "$",
+	"TWD": "$",
+	"UAH": "\u20B4",
+	"USD": "$",
+	"UYU": "$",
+	"VND": "\u20AB",
+	"XCD": "$",
+	"ZAR": "R",
+}
+
+// applyCurrencies sets CurrencyCode, CurrencyNumeric and CurrencySymbol on
+// every entry in currency_members.
+func applyCurrencies() {
+	for currency, members := range currency_members {
+		for _, a2 := range members {
+			cc := by_alpha2[a2]
+			cc.CurrencyCode = currency
+			cc.CurrencyNumeric = currency_numerics[currency]
+			cc.CurrencySymbol = currency_symbols[currency]
+			by_alpha2[a2] = cc
+		}
+	}
+}
+
+// AllByCurrency returns the entries whose primary currency is the given
+// ISO 4217 code, matched case-insensitively, sorted by alpha-2.
+func AllByCurrency(code string) []CountryCode {
+	codes := make([]CountryCode, 0)
+
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return codes
+	}
+
+	for _, cc := range All() {
+		if cc.CurrencyCode == code {
+			codes = append(codes, cc)
+		}
+	}
+
+	return codes
+}
+
+// AllByCurrencySymbol returns the entries whose primary currency is written
+// with the given symbol, e.g. "$" or "\u20AC", sorted by alpha-2. Many
+// currencies share a symbol, so "$" returns the users of every dollar and
+// peso that writes it that way.
+func AllByCurrencySymbol(sym string) []CountryCode {
+	codes := make([]CountryCode, 0)
+
+	sym = strings.TrimSpace(sym)
+	if sym == "" {
+		return codes
+	}
+
+	for _, cc := range All() {
+		if cc.CurrencySymbol == sym {
+			codes = append(codes, cc)
+		}
+	}
+
+	return codes
+}
@@ -0,0 +1,284 @@
+package countrycodes
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParsedDialing is the structured form of a DialingCode: the ITU country
+// calling code and any area codes that narrow it to this country.
+type ParsedDialing struct {
+	CountryCode string   `json:"country_code"`
+	AreaCodes   []string `json:"area_codes,omitempty"`
+}
+
+// example_e164 holds a well-formed example E.164 number for the most common
+// countries, for use as placeholder text in phone inputs. The numbers are
+// illustrative only and are not guaranteed to be unassigned.
+var example_e164 = map[string]string{
+	"AE": "+97122345678",
+	"AR": "+541123456789",
+	"AT": "+431234567890",
+	"AU": "+61212345678",
+	"BE": "+3212345678",
+	"BR": "+551123456789",
+	"CA": "+15062345678",
+	"CH": "+41212345678",
+	"CL": "+56221234567",
+	"CN": "+861012345678",
+	"CO": "+576012345678",
+	"CZ": "+420212345678",
+	"DE": "+4930123456",
+	"DK": "+4532123456",
+	"EG": "+20234567890",
+	"ES": "+34810123456",
+	"FI": "+358131234567",
+	"FR": "+33123456789",
+	"GB": "+441212345678",
+	"GR": "+302123456789",
+	"HK": "+85221234567",
+	"ID": "+62218350123",
+	"IE": "+35312345678",
+	"IL": "+97221234567",
+	"IN": "+917410410123",
+	"IT": "+390212345678",
+	"JP": "+81312345678",
+	"KE": "+254202012345",
+	"KR": "+8222123456",
+	"MA": "+212520123456",
+	"MX": "+522001234567",
+	"MY": "+60323456789",
+	"NG": "+2348021234567",
+	"NL": "+31101234567",
+	"NO": "+4721234567",
+	"NZ": "+6432345678",
+	"PE": "+5111234567",
+	"PH": "+63221234567",
+	"PK": "+922123456789",
+	"PL": "+48123456789",
+	"PT": "+351212345678",
+	"RU": "+73011234567",
+	"SA": "+966112345678",
+	"SE": "+468123456",
+	"SG": "+6561234567",
+	"TH": "+6621234567",
+	"TR": "+902123456789",
+	"TW": "+886221234567",
+	"UA": "+380311234567",
+	"US": "+14155552671",
+	"VN": "+842101234567",
+	"ZA": "+27101234567",
+}
+
+// ExampleE164 returns an example phone number for the country in E.164
+// format, e.g. "+14155552671" for the US. Only the most common countries
+// have an example; the bool is false for the rest.
+func (c CountryCode) ExampleE164() (string, bool) {
+	example, ok := example_e164[c.Alpha2]
+
+	return example, ok
+}
+
+// Dialing returns the parsed form of the country's DialingCode, so
+// "+1-809, +1-829, +1-849" becomes {"+1", ["809", "829", "849"]}. Entries
+// without a dialing code return the zero value.
+func (c CountryCode) Dialing() ParsedDialing {
+	return parseDialing(c.CallingCodes())
+}
+
+// CallingCodes returns the comma-separated parts of the country's
+// DialingCode, trimmed and each starting with "+", so "+1-787, +1-939"
+// becomes ["+1-787", "+1-939"]. Entries without a dialing code return nil.
+func (c CountryCode) CallingCodes() []string {
+	var codes []string
+
+	for _, part := range strings.Split(c.DialingCode, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if !strings.HasPrefix(part, "+") {
+			part = "+" + part
+		}
+
+		codes = append(codes, part)
+	}
+
+	return codes
+}
+
+// CallingCodeInt returns the ITU country calling code as an integer, e.g. 44
+// for GB or 1 for every NANP member. Where an entry lists several dialing
+// codes the first is used. The bool is false for entries without one.
+func (c CountryCode) CallingCodeInt() (int, bool) {
+	code := c.Dialing().CountryCode
+	if code == "" {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(code[1:])
+	if err != nil {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// GetByDialingCode returns every entry using the given dialing code, sorted
+// by alpha-2. The leading "+" is optional. A bare country calling code also
+// matches entries that add an area code to it, so "+1" returns Canada, the
+// US and every NANP territory, while "+1-268" returns only Antigua and
+// Barbuda.
+func GetByDialingCode(prefix string) ([]CountryCode, bool) {
+	prefix = strings.TrimSpace(prefix)
+	if !strings.HasPrefix(prefix, "+") {
+		prefix = "+" + prefix
+	}
+
+	matches := make([]CountryCode, 0)
+
+	for _, cc := range by_alpha2 {
+		for _, part := range cc.CallingCodes() {
+			if part == prefix || strings.HasPrefix(part, prefix+"-") {
+				matches = append(matches, cc)
+				break
+			}
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Alpha2 < matches[j].Alpha2
+	})
+
+	return matches, len(matches) > 0
+}
+
+// parseDialing splits the CallingCodes of an entry into its calling code and
+// area codes.
+func parseDialing(codes []string) ParsedDialing {
+	var parsed ParsedDialing
+
+	for _, part := range codes {
+		code, area := part, ""
+		if i := strings.Index(part, "-"); i >= 0 {
+			code, area = part[:i], part[i+1:]
+		}
+
+		if parsed.CountryCode == "" {
+			parsed.CountryCode = code
+		}
+
+		if area != "" {
+			parsed.AreaCodes = append(parsed.AreaCodes, area)
+		}
+	}
+
+	return parsed
+}
+
+// calling_code_primary picks the entry InferFromE164 returns when several
+// officially assigned entries share a calling code with no area code to tell
+// them apart, keyed by the calling code's digits.
+var calling_code_primary = map[string]string{
+	"1":   "US",
+	"7":   "RU",
+	"47":  "NO",
+	"61":  "AU",
+	"64":  "NZ",
+	"212": "MA",
+	"262": "RE",
+	"500": "FK",
+	"590": "GP",
+	"599": "CW",
+	"672": "NF",
+}
+
+// InferFromE164 returns the country an international number belongs to by
+// longest-prefix match of its digits against every calling code, so
+// "+12685551234" finds Antigua and Barbuda (+1-268) rather than the US
+// (+1). Spaces, hyphens, dots and parentheses are ignored and the leading
+// "+" is optional. Where officially assigned entries share the matched code,
+// the one in calling_code_primary wins, so "+7" numbers resolve to Russia;
+// reserved entries are only returned when nothing else matches.
+func InferFromE164(number string) (CountryCode, bool) {
+	digits := strings.Map(func(r rune) rune {
+		switch {
+		case r >= '0' && r <= '9':
+			return r
+		case r == '+' || r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+			return -1
+		}
+		return 'x'
+	}, number)
+
+	if digits == "" || !isASCIIDigits(digits) {
+		return CountryCode{}, false
+	}
+
+	var best CountryCode
+	bestLen := 0
+
+	for _, cc := range by_alpha2 {
+		for _, code := range cc.CallingCodes() {
+			prefix := strings.Replace(strings.TrimPrefix(code, "+"), "-", "", -1)
+			if !strings.HasPrefix(digits, prefix) {
+				continue
+			}
+
+			if len(prefix) > bestLen || len(prefix) == bestLen && preferCallingCode(prefix, cc, best) {
+				best, bestLen = cc, len(prefix)
+			}
+		}
+	}
+
+	return best, bestLen > 0
+}
+
+// preferCallingCode reports whether cc should replace current as the match
+// for a calling code of the same length.
+func preferCallingCode(prefix string, cc, current CountryCode) bool {
+	if cc.IsOfficiallyAssigned() != current.IsOfficiallyAssigned() {
+		return cc.IsOfficiallyAssigned()
+	}
+
+	if primary, ok := calling_code_primary[prefix]; ok && (cc.Alpha2 == primary || current.Alpha2 == primary) {
+		return cc.Alpha2 == primary
+	}
+
+	return cc.Alpha2 < current.Alpha2
+}
+
+// DialingCodes is the inverse of GetByDialingCode for country calling codes:
+// it maps every distinct calling code, such as "+1" or "+44", to the entries
+// using it, sorted by Name. Entries that add an area code are grouped under
+// their country calling code, so "+1" holds Canada, the US and every NANP
+// territory and "+44" holds GG, IM and JE along with GB. The map is built on
+// each call and may be modified by the caller.
+func DialingCodes() map[string][]CountryCode {
+	codes := make(map[string][]CountryCode)
+
+	for _, cc := range All() {
+		seen := make(map[string]bool)
+
+		for _, code := range cc.CallingCodes() {
+			if i := strings.Index(code, "-"); i >= 0 {
+				code = code[:i]
+			}
+
+			if !seen[code] {
+				seen[code] = true
+				codes[code] = append(codes[code], cc)
+			}
+		}
+	}
+
+	for _, members := range codes {
+		sort.SliceStable(members, func(i, j int) bool {
+			return members[i].Name < members[j].Name
+		})
+	}
+
+	return codes
+}
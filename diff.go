@@ -0,0 +1,25 @@
+package countrycodes
+
+import (
+	"reflect"
+)
+
+// Diff returns the fields of CountryCode whose values differ between a and
+// b, keyed by field name, with a's value first and b's second. Identical
+// entries return an empty map.
+func Diff(a, b CountryCode) map[string][2]interface{} {
+	diff := make(map[string][2]interface{})
+
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	fields := va.Type()
+
+	for i := 0; i < fields.NumField(); i++ {
+		fa, fb := va.Field(i).Interface(), vb.Field(i).Interface()
+
+		if !reflect.DeepEqual(fa, fb) {
+			diff[fields.Field(i).Name] = [2]interface{}{fa, fb}
+		}
+	}
+
+	return diff
+}
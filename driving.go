@@ -0,0 +1,56 @@
+package countrycodes
+
+// drives_on_left holds the officially assigned entries where traffic keeps
+// to the left. Territories follow their own convention where they have one,
+// as the US Virgin Islands (VI) do, and otherwise inherit the controlling
+// country's, so uninhabited British and Australian territories such as GS
+// and HM are listed. Every other entry, including all reserved and
+// user-assigned codes, is treated as driving on the right.
+var drives_on_left = map[string]bool{
+	"AG": true, "AI": true, "AU": true, "BB": true, "BD": true,
+	"BM": true, "BN": true, "BS": true, "BT": true, "BW": true,
+	"CC": true, "CK": true, "CX": true, "CY": true, "DM": true,
+	"FJ": true, "FK": true, "GB": true, "GD": true, "GG": true,
+	"GS": true, "GY": true, "HK": true, "HM": true, "ID": true,
+	"IE": true, "IM": true, "IN": true, "IO": true, "JE": true,
+	"JM": true, "JP": true, "KE": true, "KI": true, "KN": true,
+	"KY": true, "LC": true, "LK": true, "LS": true, "MO": true,
+	"MS": true, "MT": true, "MU": true, "MV": true, "MW": true,
+	"MY": true, "MZ": true, "NA": true, "NF": true, "NP": true,
+	"NR": true, "NU": true, "NZ": true, "PG": true, "PK": true,
+	"PN": true, "SB": true, "SC": true, "SG": true, "SH": true,
+	"SR": true, "SZ": true, "TC": true, "TH": true, "TK": true,
+	"TL": true, "TO": true, "TT": true, "TV": true, "TZ": true,
+	"UG": true, "VC": true, "VG": true, "VI": true, "WS": true,
+	"ZA": true, "ZM": true, "ZW": true,
+}
+
+// no_traffic_convention holds the officially assigned entries with no
+// traffic convention of their own. AllByDrivingSide returns them on neither
+// side, though their DrivesOnLeft field is false like any other.
+var no_traffic_convention = map[string]bool{
+	"AQ": true,
+}
+
+func applyDrivingSides() {
+	for a2 := range drives_on_left {
+		cc := by_alpha2[a2]
+		cc.DrivesOnLeft = true
+		by_alpha2[a2] = cc
+	}
+}
+
+// AllByDrivingSide returns the officially assigned entries that drive on
+// the left if left is true, or on the right otherwise, sorted by alpha-2.
+// Entries in no_traffic_convention, such as Antarctica, are never returned.
+func AllByDrivingSide(left bool) []CountryCode {
+	codes := make([]CountryCode, 0)
+
+	for _, cc := range All() {
+		if cc.IsOfficiallyAssigned() && !no_traffic_convention[cc.Alpha2] && cc.DrivesOnLeft == left {
+			codes = append(codes, cc)
+		}
+	}
+
+	return codes
+}
@@ -0,0 +1,67 @@
+package countrycodes
+
+// regional_indicator_a is REGIONAL INDICATOR SYMBOL LETTER A; the symbols for
+// B to Z follow it in order.
+const regional_indicator_a = 0x1F1E6
+
+// FlagEmoji returns the country's flag as a pair of regional indicator
+// symbols, e.g. "🇺🇸" for US. See FlagEmojiForAlpha2.
+func (c CountryCode) FlagEmoji() string {
+	return FlagEmojiForAlpha2(c.Alpha2)
+}
+
+// FlagEmojiForAlpha2 returns the regional indicator pair for a two-letter
+// code, ignoring case. Any two letters produce a pair, including reserved
+// codes such as EU; whether it renders as a flag is up to the font. Input
+// that is not exactly two ASCII letters returns an empty string.
+func FlagEmojiForAlpha2(a2 string) string {
+	if len(a2) != 2 || !isASCIILetters(a2) {
+		return ""
+	}
+
+	flag := make([]rune, 2)
+	for i := 0; i < 2; i++ {
+		flag[i] = rune(regional_indicator_a + int((a2[i]|0x20)-'a'))
+	}
+
+	return string(flag)
+}
+
+// Alpha2ForFlagEmoji is the reverse of FlagEmojiForAlpha2, returning the
+// upper-case code a regional indicator pair spells, e.g. "US". The bool is
+// false unless flag is exactly two regional indicator symbols; the code is
+// not checked against the dataset.
+func Alpha2ForFlagEmoji(flag string) (string, bool) {
+	runes := []rune(flag)
+	if len(runes) != 2 {
+		return "", false
+	}
+
+	a2 := make([]byte, 2)
+	for i, r := range runes {
+		if r < regional_indicator_a || r > regional_indicator_a+25 {
+			return "", false
+		}
+		a2[i] = byte('A' + r - regional_indicator_a)
+	}
+
+	return string(a2), true
+}
+
+// FlagOrCode returns FlagEmoji for officially assigned entries, whose
+// regional indicator pairs are standard emoji flags, and the alpha-2 code in
+// brackets, e.g. "[EU]", for every other entry. The zero CountryCode returns
+// an empty string.
+func (c CountryCode) FlagOrCode() string {
+	if c.IsZero() {
+		return ""
+	}
+
+	if c.IsOfficiallyAssigned() {
+		if flag := c.FlagEmoji(); flag != "" {
+			return flag
+		}
+	}
+
+	return "[" + c.Alpha2 + "]"
+}
@@ -0,0 +1,39 @@
+package countrycodes
+
+import (
+	"strings"
+)
+
+// diacritic_folds maps accented Latin letters to their unaccented base
+// letter, the equivalent of NFD decomposition with combining marks removed
+// for the characters that occur in country names.
+var diacritic_folds = map[rune]string{
+	'À': "A", 'Á': "A", 'Â': "A", 'Ã': "A", 'Ä': "A", 'Å': "A",
+	'Ç': "C", 'È': "E", 'É': "E", 'Ê': "E", 'Ë': "E",
+	'Ì': "I", 'Í': "I", 'Î': "I", 'Ï': "I", 'Ñ': "N",
+	'Ò': "O", 'Ó': "O", 'Ô': "O", 'Õ': "O", 'Ö': "O", 'Ø': "O",
+	'Ù': "U", 'Ú': "U", 'Û': "U", 'Ü': "U", 'Ý': "Y",
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a",
+	'ç': "c", 'è': "e", 'é': "e", 'ê': "e", 'ë': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ñ': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ý': "y", 'ÿ': "y",
+	'ć': "c", 'č': "c", 'š': "s", 'ž': "z", 'ș': "s", 'ț': "t",
+	'\u212B': "A", // ANGSTROM SIGN, used in the stored name of AX
+}
+
+// foldDiacritics returns s with accented letters replaced by their base
+// letters, so "Réunion" becomes "Reunion".
+func foldDiacritics(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		if fold, ok := diacritic_folds[r]; ok {
+			b.WriteString(fold)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
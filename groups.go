@@ -0,0 +1,74 @@
+package countrycodes
+
+import (
+	"strings"
+)
+
+// eu_members holds the 27 member states of the European Union. It lists
+// countries only: the EU entry in the dataset is an exceptionally reserved
+// code for the Union itself and is not a member of any group.
+var eu_members = []string{
+	"AT", "BE", "BG", "CY", "CZ", "DE", "DK", "EE", "ES", "FI", "FR", "GR",
+	"HR", "HU", "IE", "IT", "LT", "LU", "LV", "MT", "NL", "PL", "PT", "RO",
+	"SE", "SI", "SK",
+}
+
+// eea_members holds the European Economic Area: the EU plus Iceland,
+// Liechtenstein and Norway.
+var eea_members = append([]string{"IS", "LI", "NO"}, eu_members...)
+
+// schengen_members holds the states fully applying the Schengen acquis,
+// including Bulgaria and Romania since 2025.
+var schengen_members = []string{
+	"AT", "BE", "BG", "CH", "CZ", "DE", "DK", "EE", "ES", "FI", "FR", "GR",
+	"HR", "HU", "IS", "IT", "LI", "LT", "LU", "LV", "MT", "NL", "NO", "PL",
+	"PT", "RO", "SE", "SI", "SK",
+}
+
+// nato_members holds the 32 members of the North Atlantic Treaty
+// Organization.
+var nato_members = []string{
+	"AL", "BE", "BG", "CA", "CZ", "DE", "DK", "EE", "ES", "FI", "FR", "GB",
+	"GR", "HR", "HU", "IS", "IT", "LT", "LU", "LV", "ME", "MK", "NL", "NO",
+	"PL", "PT", "RO", "SE", "SI", "SK", "TR", "US",
+}
+
+// g7_members holds the Group of Seven. The EU takes part but is not a
+// member.
+var g7_members = []string{"CA", "DE", "FR", "GB", "IT", "JP", "US"}
+
+// groups maps the names AllInGroup accepts, upper-cased, to their members.
+// Memberships change over time; amend the sets above when they do.
+var groups = map[string][]string{
+	"EU":       eu_members,
+	"EEA":      eea_members,
+	"SCHENGEN": schengen_members,
+	"NATO":     nato_members,
+	"G7":       g7_members,
+}
+
+// IsEUMember reports whether the country is a member state of the European
+// Union. It is false for the EU entry itself.
+func (c CountryCode) IsEUMember() bool {
+	return isMember(groups["EU"], c.Alpha2)
+}
+
+// AllInGroup returns the member countries of the named group, matched
+// case-insensitively, sorted by alpha-2. Supported groups are "EU", "EEA",
+// "Schengen", "NATO" and "G7"; any other name returns an empty slice.
+func AllInGroup(group string) []CountryCode {
+	codes := make([]CountryCode, 0)
+
+	members := make(map[string]bool)
+	for _, a2 := range groups[strings.ToUpper(strings.TrimSpace(group))] {
+		members[a2] = true
+	}
+
+	for _, cc := range All() {
+		if members[cc.Alpha2] {
+			codes = append(codes, cc)
+		}
+	}
+
+	return codes
+}
@@ -0,0 +1,94 @@
+package countrycodes
+
+import (
+	"strings"
+)
+
+// numeric_history holds, current code first, the ISO 3166-1 numeric codes an
+// entry has carried over time, for the countries whose numeric changed:
+//
+//	DE 280: West Germany before reunification in 1990
+//	ET 230: Ethiopia including Eritrea, before 1993
+//	PA 590: Panama before the Canal Zone was merged in 1979
+//	SD 736: Sudan including South Sudan, before 2011
+//	YE 886: Yemen Arab Republic (North Yemen), before 1990
+var numeric_history = map[string][]int{
+	"DE": {276, 280},
+	"ET": {231, 230},
+	"PA": {591, 590},
+	"SD": {729, 736},
+	"YE": {887, 886},
+}
+
+// NumericHistory returns the numeric codes historically associated with the
+// given alpha-2 code, current code first. For most countries that is just
+// the current Numeric; it is nil for unknown codes and for entries without
+// a numeric code.
+func NumericHistory(alpha2 string) []int {
+	alpha2 = strings.ToUpper(strings.TrimSpace(alpha2))
+
+	if history, ok := numeric_history[alpha2]; ok {
+		return append([]int(nil), history...)
+	}
+
+	code, ok := GetByAlpha2(alpha2)
+	if !ok || code.Numeric <= 0 {
+		return nil
+	}
+
+	return []int{code.Numeric}
+}
+
+// successors maps deleted or superseded entries to the entries that replaced
+// them, primary successor first. Splits list every successor: AN became CW,
+// SX and BQ; CS and YU became RS and ME; NT was divided between SA and IQ;
+// and SU lists RU followed by the other former Soviet republics.
+var successors = map[string][]string{
+	"AN": {"CW", "SX", "BQ"},
+	"BU": {"MM"},
+	"CS": {"RS", "ME"},
+	"FX": {"FR"},
+	"NT": {"SA", "IQ"},
+	"SF": {"FI"},
+	"SU": {"RU", "AM", "AZ", "BY", "EE", "GE", "KG", "KZ", "LT", "LV", "MD", "TJ", "TM", "UA", "UZ"},
+	"TP": {"TL"},
+	"YU": {"RS", "ME"},
+	"ZR": {"CD"},
+}
+
+// Successor returns the entry that replaced this one, e.g. MM for BU. For
+// entries that split, it returns the primary successor; see Successors. The
+// bool is false for entries that have not been replaced.
+func (c CountryCode) Successor() (CountryCode, bool) {
+	codes := c.Successors()
+	if len(codes) == 0 {
+		return CountryCode{}, false
+	}
+
+	return codes[0], true
+}
+
+// Successors returns every entry that replaced this one, primary first, or
+// nil for entries that have not been replaced.
+func (c CountryCode) Successors() []CountryCode {
+	var codes []CountryCode
+
+	for _, a2 := range successors[c.Alpha2] {
+		if code, ok := GetByAlpha2(a2); ok {
+			codes = append(codes, code)
+		}
+	}
+
+	return codes
+}
+
+// SuccessorOf returns the primary successor of the entry with the given
+// alpha-2 code, so historical records can be normalized automatically.
+func SuccessorOf(a2 string) (CountryCode, bool) {
+	code, ok := GetByAlpha2(strings.ToUpper(strings.TrimSpace(a2)))
+	if !ok {
+		return CountryCode{}, false
+	}
+
+	return code.Successor()
+}
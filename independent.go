@@ -0,0 +1,43 @@
+package countrycodes
+
+// dependent holds the officially assigned entries that ISO 3166-1 marks as
+// not independent. Every other officially assigned entry is independent;
+// reserved and user-assigned entries are never classified as independent.
+//
+// Borderline cases follow ISO rather than any recognition count: Taiwan (TW)
+// and Palestine (PS) are marked not independent and are listed here, Kosovo
+// (XK) is only user assigned and so is never independent, and the Holy See
+// (VA) is independent. The result is the 193 UN member states plus VA.
+var dependent = map[string]bool{
+	"AI": true, "AQ": true, "AS": true, "AW": true, "AX": true,
+	"BL": true, "BM": true, "BQ": true, "BV": true, "CC": true,
+	"CK": true, "CW": true, "CX": true, "EH": true, "FK": true,
+	"FO": true, "GF": true, "GG": true, "GI": true, "GL": true,
+	"GP": true, "GS": true, "GU": true, "HK": true, "HM": true,
+	"IM": true, "IO": true, "JE": true, "KY": true, "MF": true,
+	"MO": true, "MP": true, "MQ": true, "MS": true, "NC": true,
+	"NF": true, "NU": true, "PF": true, "PM": true, "PN": true,
+	"PR": true, "PS": true, "RE": true, "SH": true, "SJ": true,
+	"SX": true, "TC": true, "TF": true, "TK": true, "TW": true,
+	"UM": true, "VG": true, "VI": true, "WF": true, "YT": true,
+}
+
+// IndependentCountries returns every entry ISO 3166-1 marks as independent,
+// sorted by alpha-2.
+func IndependentCountries() []CountryCode {
+	countries := make([]CountryCode, 0)
+
+	for _, cc := range All() {
+		if cc.Independent {
+			countries = append(countries, cc)
+		}
+	}
+
+	return countries
+}
+
+// AllIndependent is IndependentCountries, named to match the other AllBy
+// functions.
+func AllIndependent() []CountryCode {
+	return IndependentCountries()
+}
@@ -0,0 +1,46 @@
+package countrycodes
+
+// CountryInfo is the consolidated description of one entry returned by
+// InfoPayload, shaped for JSON APIs. Fields with no data are omitted from
+// the JSON.
+type CountryInfo struct {
+	Alpha2         string         `json:"alpha2"`
+	Alpha3         string         `json:"alpha3,omitempty"`
+	Numeric        int            `json:"numeric,omitempty"`
+	Name           string         `json:"name"`
+	Flag           string         `json:"flag,omitempty"`
+	Dialing        *ParsedDialing `json:"dialing,omitempty"`
+	Region         string         `json:"region,omitempty"`
+	CurrencyCode   string         `json:"currency_code,omitempty"`
+	CurrencySymbol string         `json:"currency_symbol,omitempty"`
+	Assignment     string         `json:"assignment"`
+}
+
+// InfoPayload bundles the entry's codes, name, flag emoji, parsed dialing
+// code, world region, currency and assignment into a CountryInfo. Numeric
+// codes of zero or less, as held by the reserved entries, are omitted.
+func (c CountryCode) InfoPayload() CountryInfo {
+	info := CountryInfo{
+		Alpha2:         c.Alpha2,
+		Alpha3:         c.Alpha3,
+		Name:           c.Name,
+		Flag:           c.FlagEmoji(),
+		CurrencyCode:   c.CurrencyCode,
+		CurrencySymbol: c.CurrencySymbol,
+		Assignment:     c.Assignment.String(),
+	}
+
+	if c.Numeric > 0 {
+		info.Numeric = c.Numeric
+	}
+
+	if dialing := c.Dialing(); dialing.CountryCode != "" {
+		info.Dialing = &dialing
+	}
+
+	if c.Region != RegionNone {
+		info.Region = c.Region.String()
+	}
+
+	return info
+}
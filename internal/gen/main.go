@@ -0,0 +1,241 @@
+// Command gen regenerates the by_alpha2 table literal in country-codes.go
+// from the embedded iso3166.csv, so that data updates are made to the CSV
+// and never by hand-editing the Go literal.
+//
+// It is run from the package directory via "go generate".
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+//go:embed iso3166.csv
+var isoCSV []byte
+
+const (
+	tableStart = "\tby_alpha2 = map[string]CountryCode{\n"
+	tableEnd   = "\n\t}\n"
+)
+
+var assignmentLabels = map[string]string{
+	"OFFICIALLY_ASSIGNED":      "Officially assigned",
+	"USER_ASSIGNED":            "User assigned",
+	"EXCEPTIONALLY_RESERVED":   "Exceptionally reserved",
+	"TRANSITIONALLY_RESERVED":  "Transitionally reserved",
+	"INDETERMINATELY_RESERVED": "Indeterminately reserved",
+	"NOT_USED":                 "Not used",
+}
+
+var htmlEntities = map[rune]string{
+	'&':      "&amp;",
+	'<':      "&lt;",
+	'>':      "&gt;",
+	'\u00C5': "&Aring;",
+	'\u00E7': "&ccedil;",
+	'\u00E9': "&eacute;",
+	'\u00F4': "&ocirc;",
+	'\u212B': "&Aring;",
+}
+
+type entry struct {
+	alpha2      string
+	alpha3      string
+	numeric     int
+	name        string
+	dialingCode string
+	assignment  string
+	wikipedia   []string
+	see         []string
+}
+
+func main() {
+	path := flag.String("o", "country-codes.go", "Go source file holding the by_alpha2 table")
+	flag.Parse()
+
+	src, err := ioutil.ReadFile(*path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out, err := generate(src, isoCSV)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(*path, out, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate replaces the by_alpha2 literal in src with one rendered from the
+// CSV data and returns the gofmt-formatted result.
+func generate(src, data []byte) ([]byte, error) {
+	start := bytes.Index(src, []byte(tableStart))
+	if start < 0 {
+		return nil, errors.New("gen: by_alpha2 table not found")
+	}
+	start += len(tableStart)
+
+	end := bytes.Index(src[start:], []byte(tableEnd))
+	if end < 0 {
+		return nil, errors.New("gen: end of by_alpha2 table not found")
+	}
+	end += start
+
+	entries, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(src[:start])
+	for i, e := range entries {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		render(&buf, e)
+	}
+	buf.Write(src[end+1:])
+
+	return format.Source(buf.Bytes())
+}
+
+func parse(data []byte) ([]entry, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("gen: empty CSV")
+	}
+
+	entries := make([]entry, 0, len(records)-1)
+	seen := make(map[string]bool)
+
+	for i, rec := range records[1:] {
+		line := i + 2
+
+		if len(rec) != 8 {
+			return nil, fmt.Errorf("gen: line %d: expected 8 fields, got %d", line, len(rec))
+		}
+
+		numeric, err := strconv.Atoi(rec[2])
+		if err != nil {
+			return nil, fmt.Errorf("gen: line %d: bad numeric %q", line, rec[2])
+		}
+
+		if _, ok := assignmentLabels[rec[5]]; !ok {
+			return nil, fmt.Errorf("gen: line %d: unknown assignment %q", line, rec[5])
+		}
+
+		if seen[rec[0]] {
+			return nil, fmt.Errorf("gen: line %d: duplicate alpha-2 %q", line, rec[0])
+		}
+		seen[rec[0]] = true
+
+		entries = append(entries, entry{
+			alpha2:      rec[0],
+			alpha3:      rec[1],
+			numeric:     numeric,
+			name:        rec[3],
+			dialingCode: rec[4],
+			assignment:  rec[5],
+			wikipedia:   split(rec[6]),
+			see:         split(rec[7]),
+		})
+	}
+
+	return entries, nil
+}
+
+func render(buf *bytes.Buffer, e entry) {
+	links := make([]string, len(e.wikipedia))
+	for i, page := range e.wikipedia {
+		text := htmlEscape(e.name)
+		if len(e.wikipedia) > 1 {
+			text, _ = url.PathUnescape(page)
+			text = strings.Replace(text, "_", " ", -1)
+		}
+		links[i] = fmt.Sprintf("<a href=\"http://en.wikipedia.org/wiki/%s\">%s</a>", page, text)
+	}
+
+	alpha3 := e.alpha3
+	if alpha3 == "" {
+		alpha3 = "null"
+	}
+
+	buf.WriteString("\t\t/**\n")
+	fmt.Fprintf(buf, "\t\t * %s\n", strings.Join(links, ",\n\t\t * "))
+	fmt.Fprintf(buf, "\t\t * [<a href=\"http://en.wikipedia.org/wiki/ISO_3166-1_alpha-2#%s\">%s</a>, %s, %d,\n",
+		e.alpha2, e.alpha2, alpha3, e.numeric)
+	fmt.Fprintf(buf, "\t\t * %s]\n", assignmentLabels[e.assignment])
+	for _, see := range e.see {
+		fmt.Fprintf(buf, "\t\t *\n\t\t * @see #%s\n", see)
+	}
+	if e.numeric == 0 {
+		buf.WriteString("\t\t *\n\t\t * <p>\n\t\t * ISO 3166-1 numeric code is unknown.\n\t\t * </p>\n")
+	}
+	buf.WriteString("\t\t */\n")
+
+	fmt.Fprintf(buf, "\t\t%q: CountryCode{\n", e.alpha2)
+	fmt.Fprintf(buf, "\t\t\tName: %s,\n", goQuote(e.name))
+	fmt.Fprintf(buf, "\t\t\tAlpha2: %q,\n", e.alpha2)
+	fmt.Fprintf(buf, "\t\t\tAlpha3: %q,\n", e.alpha3)
+	fmt.Fprintf(buf, "\t\t\tNumeric: %d,\n", e.numeric)
+	fmt.Fprintf(buf, "\t\t\tDialingCode: %q,\n", e.dialingCode)
+	fmt.Fprintf(buf, "\t\t\tAssignment: %s,\n", e.assignment)
+	buf.WriteString("\t\t},\n")
+}
+
+func split(field string) []string {
+	if field == "" {
+		return nil
+	}
+	return strings.Split(field, ";")
+}
+
+func htmlEscape(s string) string {
+	var buf bytes.Buffer
+	for _, r := range s {
+		if entity, ok := htmlEntities[r]; ok {
+			buf.WriteString(entity)
+		} else if r >= 0x80 {
+			fmt.Fprintf(&buf, "&#%d;", r)
+		} else {
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
+
+// goQuote quotes s as a Go string literal, writing non-ASCII characters as
+// upper-case \u escapes to match the existing table.
+func goQuote(s string) string {
+	var buf bytes.Buffer
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case r < 0x20 || r >= 0x7F:
+			fmt.Fprintf(&buf, "\\u%04X", r)
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
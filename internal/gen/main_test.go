@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"io/ioutil"
+	"testing"
+)
+
+func TestGeneratedTableMatchesCheckedIn(t *testing.T) {
+	src, err := ioutil.ReadFile("../../country-codes.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := generate(src, isoCSV)
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	formatted, err := format.Source(out)
+	if err != nil {
+		t.Fatalf("Generated source does not parse: %v", err)
+	}
+
+	if !bytes.Equal(formatted, out) {
+		t.Fatalf("Generated source is not gofmt-stable")
+	}
+
+	if !bytes.Equal(out, src) {
+		t.Fatalf("country-codes.go is out of date with iso3166.csv; run go generate")
+	}
+}
+
+func TestParseRejectsUnknownAssignment(t *testing.T) {
+	data := []byte("alpha2,alpha3,numeric,name,dialing_code,assignment,wikipedia,see\n" +
+		"ZZ,ZZZ,999,Nowhere,,BOGUS,Nowhere,\n")
+
+	if _, err := parse(data); err == nil {
+		t.Fatalf("Unknown assignment accepted")
+	}
+}
@@ -0,0 +1,132 @@
+package countrycodes
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MarshalJSON encodes the country as its alpha-2 code. The zero CountryCode
+// is encoded as null.
+func (c CountryCode) MarshalJSON() ([]byte, error) {
+	if c.Alpha2 == "" {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(c.Alpha2)
+}
+
+// UnmarshalJSON decodes a country from an alpha-2 or alpha-3 string, matched
+// case-insensitively, or from a numeric code given as a JSON number. null
+// leaves the zero CountryCode; an unknown code returns an error wrapping
+// ErrNotFound.
+func (c *CountryCode) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*c = CountryCode{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		var code CountryCode
+
+		switch ClassifyInput(s) {
+		case KindAlpha2:
+			code, err = LookupByAlpha2(strings.ToUpper(strings.TrimSpace(s)))
+		case KindAlpha3:
+			code, err = LookupByAlpha3(strings.ToUpper(strings.TrimSpace(s)))
+		default:
+			return fmt.Errorf("countrycodes: %q is not an alpha-2 or alpha-3 code", s)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		*c = code
+		return nil
+	}
+
+	var n int
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("countrycodes: country must be a string or integer, got %s", data)
+	}
+
+	if !IsValidNumeric(n) {
+		return fmt.Errorf("%w: numeric %d", ErrNotFound, n)
+	}
+
+	code, err := LookupByNumeric(n)
+	if err != nil {
+		return err
+	}
+
+	*c = code
+
+	return nil
+}
+
+// datasetEntry is the shape of one entry in MarshalDataset's output. Its
+// JSON field names are part of the output format and must not change.
+type datasetEntry struct {
+	Name            string     `json:"name"`
+	Alpha2          string     `json:"alpha2"`
+	Alpha3          string     `json:"alpha3"`
+	Numeric         int        `json:"numeric"`
+	DialingCode     string     `json:"dialing_code"`
+	Assignment      Assignment `json:"assignment"`
+	Independent     bool       `json:"independent"`
+	Continent       string     `json:"continent"`
+	RegionCode      int        `json:"region_code"`
+	Region          string     `json:"region"`
+	CurrencyCode    string     `json:"currency_code"`
+	CurrencyNumeric int        `json:"currency_numeric"`
+	CurrencySymbol  string     `json:"currency_symbol"`
+	TLD             string     `json:"tld"`
+	Languages       []string   `json:"languages"`
+	Borders         []string   `json:"borders"`
+	DrivesOnLeft    bool       `json:"drives_on_left"`
+	Latitude        float64    `json:"latitude"`
+	Longitude       float64    `json:"longitude"`
+	Timezones       []string   `json:"timezones"`
+}
+
+// MarshalDataset returns the whole dataset as a JSON array of objects sorted
+// by alpha-2, with every field of CountryCode under a fixed snake_case name.
+// Assignment is written as its constant name, Region as its name or "" for
+// RegionNone, and missing Languages, Borders and Timezones as empty arrays.
+func MarshalDataset() ([]byte, error) {
+	entries := make([]datasetEntry, 0, Count())
+
+	for _, cc := range All() {
+		entry := datasetEntry{
+			Name:            cc.Name,
+			Alpha2:          cc.Alpha2,
+			Alpha3:          cc.Alpha3,
+			Numeric:         cc.Numeric,
+			DialingCode:     cc.DialingCode,
+			Assignment:      cc.Assignment,
+			Independent:     cc.Independent,
+			Continent:       cc.Continent,
+			RegionCode:      cc.RegionCode,
+			CurrencyCode:    cc.CurrencyCode,
+			CurrencyNumeric: cc.CurrencyNumeric,
+			CurrencySymbol:  cc.CurrencySymbol,
+			TLD:             cc.TLD,
+			Languages:       append([]string{}, cc.Languages()...),
+			Borders:         append([]string{}, cc.Borders()...),
+			DrivesOnLeft:    cc.DrivesOnLeft,
+			Latitude:        cc.Latitude,
+			Longitude:       cc.Longitude,
+			Timezones:       append([]string{}, cc.Timezones()...),
+		}
+
+		if cc.Region != RegionNone {
+			entry.Region = cc.Region.String()
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return json.Marshal(entries)
+}
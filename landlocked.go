@@ -0,0 +1,34 @@
+package countrycodes
+
+// landlocked holds the officially assigned entries with no coastline on the
+// open sea. The Caspian states (AZ, KZ, TM) are counted as landlocked.
+var landlocked = map[string]bool{
+	"AD": true, "AF": true, "AM": true, "AT": true, "AZ": true,
+	"BF": true, "BI": true, "BO": true, "BT": true, "BW": true,
+	"BY": true, "CF": true, "CH": true, "CZ": true, "ET": true,
+	"HU": true, "KG": true, "KZ": true, "LA": true, "LI": true,
+	"LS": true, "LU": true, "MD": true, "MK": true, "ML": true,
+	"MN": true, "MW": true, "NE": true, "NP": true, "PY": true,
+	"RS": true, "RW": true, "SK": true, "SM": true, "SS": true,
+	"SZ": true, "TD": true, "TJ": true, "TM": true, "UG": true,
+	"UZ": true, "VA": true, "ZM": true, "ZW": true,
+}
+
+// IsLandlocked reports whether the country has no coastline on the open sea.
+func (c CountryCode) IsLandlocked() bool {
+	return landlocked[c.Alpha2]
+}
+
+// CoastalCountries returns every officially assigned entry that is not
+// landlocked, sorted by alpha-2.
+func CoastalCountries() []CountryCode {
+	coastal := make([]CountryCode, 0)
+
+	for _, cc := range All() {
+		if cc.Assignment == OFFICIALLY_ASSIGNED && !cc.IsLandlocked() {
+			coastal = append(coastal, cc)
+		}
+	}
+
+	return coastal
+}
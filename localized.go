@@ -0,0 +1,555 @@
+package countrycodes
+
+import (
+	"strings"
+)
+
+// localized_names holds the names of the officially assigned entries in
+// other languages, keyed by ISO 639-1 code and then alpha-2. They follow
+// the ISO 3166-1 translations published by the Debian iso-codes project, so
+// inverted forms such as the German "Korea, Republik" are kept. Names that
+// are the same as Name are left out.
+var localized_names = map[string]map[string]string{
+	"de": {
+		"AE": "Vereinigte Arabische Emirate",
+		"AG": "Antigua und Barbuda",
+		"AL": "Albanien",
+		"AM": "Armenien",
+		"AQ": "Antarktis",
+		"AR": "Argentinien",
+		"AS": "Amerikanisch-Samoa",
+		"AT": "\u00D6sterreich",
+		"AU": "Australien",
+		"AX": "\u00C5land-Inseln",
+		"AZ": "Aserbaidschan",
+		"BA": "Bosnien und Herzegowina",
+		"BD": "Bangladesch",
+		"BE": "Belgien",
+		"BG": "Bulgarien",
+		"BL": "Saint-Barth\u00E9lemy",
+		"BO": "Bolivien, Plurinationaler Staat",
+		"BQ": "Bonaire, Sint Eustatius und Saba",
+		"BR": "Brasilien",
+		"BV": "Bouvet-Insel",
+		"BW": "Botsuana",
+		"CA": "Kanada",
+		"CC": "Kokos-(Keeling-)Inseln",
+		"CD": "Demokratische Republik Kongo",
+		"CF": "Zentralafrikanische Republik",
+		"CG": "Kongo",
+		"CH": "Schweiz",
+		"CK": "Cookinseln",
+		"CM": "Kamerun",
+		"CO": "Kolumbien",
+		"CU": "Kuba",
+		"CV": "Kap Verde",
+		"CX": "Weihnachtsinseln",
+		"CY": "Zypern",
+		"CZ": "Tschechien",
+		"DE": "Deutschland",
+		"DJ": "Dschibuti",
+		"DK": "D\u00E4nemark",
+		"DO": "Dominikanische Republik",
+		"DZ": "Algerien",
+		"EE": "Estland",
+		"EG": "\u00C4gypten",
+		"EH": "Westsahara",
+		"ES": "Spanien",
+		"ET": "\u00C4thiopien",
+		"FI": "Finnland",
+		"FJ": "Fidschi",
+		"FK": "Falklandinseln (Malwinen)",
+		"FM": "Mikronesien, F\u00F6derierte Staaten von",
+		"FO": "F\u00E4r\u00F6er-Inseln",
+		"FR": "Frankreich",
+		"GA": "Gabun",
+		"GB": "Vereinigtes K\u00F6nigreich",
+		"GE": "Georgien",
+		"GF": "Franz\u00F6sisch-Guyana",
+		"GL": "Gr\u00F6nland",
+		"GQ": "\u00C4quatorialguinea",
+		"GR": "Griechenland",
+		"GS": "South Georgia und die S\u00FCdlichen Sandwichinseln",
+		"HK": "Hongkong",
+		"HM": "Heard und McDonaldinseln",
+		"HR": "Kroatien",
+		"HU": "Ungarn",
+		"ID": "Indonesien",
+		"IE": "Irland",
+		"IM": "Insel Man",
+		"IN": "Indien",
+		"IO": "Britisches Territorium im Indischen Ozean",
+		"IQ": "Irak",
+		"IR": "Iran, Islamische Republik",
+		"IS": "Island",
+		"IT": "Italien",
+		"JM": "Jamaika",
+		"JO": "Jordanien",
+		"KE": "Kenia",
+		"KG": "Kirgisistan",
+		"KH": "Kambodscha",
+		"KM": "Komoren",
+		"KN": "St. Kitts und Nevis",
+		"KP": "Korea, Demokratische Volksrepublik",
+		"KR": "Korea, Republik",
+		"KY": "Cayman-Inseln",
+		"KZ": "Kasachstan",
+		"LA": "Laos, Demokratische Volksrepublik",
+		"LB": "Libanon",
+		"LC": "St. Lucia",
+		"LT": "Litauen",
+		"LU": "Luxemburg",
+		"LV": "Lettland",
+		"LY": "Libyen",
+		"MA": "Marokko",
+		"MD": "Moldau, Republik",
+		"MF": "Saint Martin (Franz\u00F6sischer Teil)",
+		"MG": "Madagaskar",
+		"MH": "Marshallinseln",
+		"MK": "Nordmazedonien",
+		"MN": "Mongolei",
+		"MP": "N\u00F6rdliche Marianen",
+		"MR": "Mauretanien",
+		"MV": "Malediven",
+		"MX": "Mexiko",
+		"MZ": "Mosambik",
+		"NC": "Neukaledonien",
+		"NF": "Norfolkinsel",
+		"NL": "Niederlande",
+		"NO": "Norwegen",
+		"NZ": "Neuseeland",
+		"PF": "Franz\u00F6sisch-Polynesien",
+		"PG": "Papua-Neuguinea",
+		"PH": "Philippinen",
+		"PL": "Polen",
+		"PM": "St. Pierre und Miquelon",
+		"PS": "Pal\u00E4stina, Staat",
+		"QA": "Katar",
+		"RO": "Rum\u00E4nien",
+		"RS": "Serbien",
+		"RU": "Russische F\u00F6deration",
+		"RW": "Ruanda",
+		"SA": "Saudi-Arabien",
+		"SB": "Salomoninseln",
+		"SC": "Seychellen",
+		"SE": "Schweden",
+		"SG": "Singapur",
+		"SH": "St. Helena, Ascension und Tristan da Cunha",
+		"SI": "Slowenien",
+		"SJ": "Svalbard und Jan Mayen",
+		"SK": "Slowakei",
+		"SS": "S\u00FCdsudan",
+		"ST": "S\u00E3o Tom\u00E9 und Pr\u00EDncipe",
+		"SX": "Saint-Martin (Niederl\u00E4ndischer Teil)",
+		"SY": "Syrien, Arabische Republik",
+		"SZ": "Eswatini",
+		"TC": "Turks- und Caicosinseln",
+		"TD": "Tschad",
+		"TF": "Franz\u00F6sische S\u00FCd- und Antarktisgebiete",
+		"TJ": "Tadschikistan",
+		"TN": "Tunesien",
+		"TR": "T\u00FCrkei",
+		"TT": "Trinidad und Tobago",
+		"TW": "Taiwan, Chinesische Provinz",
+		"TZ": "Tansania, Vereinigte Republik",
+		"US": "Vereinigte Staaten",
+		"UZ": "Usbekistan",
+		"VA": "Heiliger Stuhl (Staat Vatikanstadt)",
+		"VC": "St. Vincent und die Grenadinen",
+		"VE": "Venezuela, Bolivarische Republik",
+		"VG": "Britische Jungferninseln",
+		"VI": "Amerikanische Jungferninseln",
+		"VN": "Vietnam",
+		"WF": "Wallis und Futuna",
+		"YE": "Jemen",
+		"ZA": "S\u00FCdafrika",
+		"ZM": "Sambia",
+		"ZW": "Simbabwe",
+	},
+	"es": {
+		"AE": "Emiratos \u00C1rabes Unidos",
+		"AF": "Afganist\u00E1n",
+		"AG": "Antigua y Barbuda",
+		"AI": "Anguila",
+		"AQ": "Ant\u00E1rtida",
+		"AS": "Samoa Estadounidense",
+		"AX": "Islas \u00C4land",
+		"AZ": "Azerbaiy\u00E1n",
+		"BA": "Bosnia y Herzegovina",
+		"BD": "Banglad\u00E9s",
+		"BE": "B\u00E9lgica",
+		"BF": "Burquina Faso",
+		"BH": "Bar\u00E9in",
+		"BJ": "Ben\u00EDn",
+		"BL": "San Bartolom\u00E9",
+		"BM": "Islas Bermudas",
+		"BO": "Bolivia, Estado plurinacional de",
+		"BQ": "Islas BES (Caribe Neerland\u00E9s)",
+		"BR": "Brasil",
+		"BT": "But\u00E1n",
+		"BV": "Isla Bouvet",
+		"BW": "Botsuana",
+		"BY": "Bielorrusia",
+		"BZ": "Belice",
+		"CA": "Canad\u00E1",
+		"CC": "Islas Cocos (Keeling)",
+		"CD": "Congo, Rep\u00FAblica Democr\u00E1tica del",
+		"CF": "Rep\u00FAblica Centroafricana",
+		"CH": "Suiza",
+		"CI": "Costa de Marfil",
+		"CK": "Islas Cook",
+		"CM": "Camer\u00FAn",
+		"CV": "Cabo Verde",
+		"CW": "Curazao",
+		"CX": "Isla de Navidad",
+		"CY": "Chipre",
+		"CZ": "Chequia",
+		"DE": "Alemania",
+		"DJ": "Yibuti",
+		"DK": "Dinamarca",
+		"DO": "Rep\u00FAblica Dominicana",
+		"EG": "Egipto",
+		"EH": "Sahara Occidental",
+		"ES": "Espa\u00F1a",
+		"ET": "Etiop\u00EDa",
+		"FI": "Finlandia",
+		"FJ": "Fiyi",
+		"FK": "Islas Falkland (Malvinas)",
+		"FM": "Micronesia, Estados Federados de",
+		"FO": "Islas Feroe",
+		"FR": "Francia",
+		"GA": "Gab\u00F3n",
+		"GB": "Reino Unido",
+		"GD": "Granada",
+		"GF": "Guayana Francesa",
+		"GL": "Groenlandia",
+		"GP": "Guadalupe",
+		"GQ": "Guinea Ecuatorial",
+		"GR": "Grecia",
+		"GS": "Islas Georgias del Sur y S\u00E1ndwich del Sur",
+		"GW": "Guinea-Bis\u00E1u",
+		"HM": "Islas Heard y McDonald",
+		"HR": "Croacia",
+		"HT": "Hait\u00ED",
+		"HU": "Hungr\u00EDa",
+		"IE": "Irlanda",
+		"IM": "Isla de Man",
+		"IO": "Territorio Brit\u00E1nico del Oc\u00E9ano \u00CDndico",
+		"IQ": "Irak",
+		"IR": "Ir\u00E1n, Rep\u00FAblica isl\u00E1mica de",
+		"IS": "Islandia",
+		"IT": "Italia",
+		"JO": "Jordania",
+		"JP": "Jap\u00F3n",
+		"KE": "Kenia",
+		"KG": "Kirguist\u00E1n",
+		"KH": "Camboya",
+		"KM": "Comores, Islas",
+		"KN": "San Crist\u00F3bal y Nieves",
+		"KP": "Corea, Rep\u00FAblica Democr\u00E1tica Popular de",
+		"KR": "Corea, Rep\u00FAblica de",
+		"KY": "Islas Caim\u00E1n",
+		"KZ": "Kazajist\u00E1n",
+		"LA": "Rep\u00FAblica Democr\u00E1tica Popular de Lao",
+		"LB": "L\u00EDbano",
+		"LC": "Santa Luc\u00EDa",
+		"LS": "Lesoto",
+		"LT": "Lituania",
+		"LU": "Luxemburgo",
+		"LV": "Letonia",
+		"LY": "Libia",
+		"MA": "Marruecos",
+		"MC": "M\u00F3naco",
+		"MD": "Moldavia, Rep\u00FAblica de",
+		"MF": "San Mart\u00EDn (zona francesa)",
+		"MH": "Islas Marshall",
+		"MK": "Macedonia del Norte",
+		"ML": "Mal\u00ED",
+		"MM": "Birmania",
+		"MP": "Islas Marianas del Norte",
+		"MQ": "Martinica",
+		"MU": "Mauricio",
+		"MV": "Islas Maldivas",
+		"MW": "Malaui",
+		"MX": "M\u00E9xico",
+		"MY": "Malasia",
+		"NC": "Nueva Caledonia",
+		"NF": "Isla Norfolk",
+		"NL": "Pa\u00EDses Bajos",
+		"NO": "Noruega",
+		"NZ": "Nueva Zelanda",
+		"OM": "Om\u00E1n",
+		"PA": "Panam\u00E1",
+		"PE": "Per\u00FA",
+		"PF": "Polinesia Francesa",
+		"PG": "Pap\u00FAa Nueva Guinea",
+		"PH": "Filipinas",
+		"PK": "Pakist\u00E1n",
+		"PL": "Polonia",
+		"PM": "San Pedro y Miquelon",
+		"PS": "Palestina, Estado de",
+		"PW": "Palaos",
+		"QA": "Catar",
+		"RE": "Reuni\u00F3n",
+		"RO": "Ruman\u00EDa",
+		"RU": "Federaci\u00F3n Rusa",
+		"RW": "Ruanda",
+		"SA": "Arabia Saud\u00ED",
+		"SB": "Islas Salom\u00F3n",
+		"SD": "Sud\u00E1n",
+		"SE": "Suecia",
+		"SG": "Singapur",
+		"SH": "Santa Elena, Ascensi\u00F3n y Trist\u00E1n de Acu\u00F1a",
+		"SI": "Eslovenia",
+		"SJ": "Svalbard y Jan Mayen",
+		"SK": "Eslovaquia",
+		"SL": "Sierra Leona",
+		"SR": "Surin\u00E1m",
+		"SS": "Sud\u00E1n del Sur",
+		"ST": "Santo Tom\u00E9 y Pr\u00EDncipe",
+		"SX": "Isla de San Mart\u00EDn (zona holandsea)",
+		"SY": "Rep\u00FAblica \u00E1rabe de Siria",
+		"SZ": "Esuatini",
+		"TC": "Islas Turcas y Caicos",
+		"TF": "Territorios Franceses del Sur",
+		"TH": "Tailandia",
+		"TJ": "Tayikist\u00E1n",
+		"TL": "Timor Oriental",
+		"TM": "Turkmenist\u00E1n",
+		"TN": "Tunez",
+		"TR": "T\u00FCrkiye",
+		"TT": "Trinidad y Tobago",
+		"TW": "Taiw\u00E1n, Provincia de China",
+		"TZ": "Tanzania, Rep\u00FAblica unida de",
+		"UA": "Ucrania",
+		"UM": "Islas Ultramarinas Menores de Estados Unidos",
+		"US": "Estados Unidos",
+		"UZ": "Uzbekist\u00E1n",
+		"VA": "Santa Sede (Ciudad Estado del Vaticano)",
+		"VC": "San Vicente y las Granadinas",
+		"VE": "Venezuela, Rep\u00FAblica Bolivariana de",
+		"VG": "Islas V\u00EDrgenes, Brit\u00E1nicas",
+		"VI": "Islas V\u00EDrgenes, de EEUU",
+		"VN": "Vietnam",
+		"WF": "Wallis y Futuna",
+		"ZA": "Sud\u00E1frica",
+		"ZW": "Zimbabue",
+	},
+	"fr": {
+		"AD": "Andorre",
+		"AE": "\u00C9mirats arabes unis",
+		"AG": "Antigua-et-Barbuda",
+		"AL": "Albanie",
+		"AM": "Arm\u00E9nie",
+		"AQ": "Antarctique",
+		"AR": "Argentine",
+		"AS": "Samoa am\u00E9ricaines",
+		"AT": "Autriche",
+		"AU": "Australie",
+		"AX": "\u00C5land, \u00CEles",
+		"AZ": "Azerba\u00EFdjan",
+		"BA": "Bosnie-Herz\u00E9govine",
+		"BB": "Barbade",
+		"BE": "Belgique",
+		"BG": "Bulgarie",
+		"BH": "Bahre\u00EFn",
+		"BJ": "B\u00E9nin",
+		"BL": "Saint-Barth\u00E9lemy",
+		"BM": "Bermudes",
+		"BN": "Brun\u00E9i Darussalam",
+		"BO": "Bolivie, \u00E9tat plurinational de",
+		"BQ": "Bonaire, Saint-Eustache et Saba",
+		"BR": "Br\u00E9sil",
+		"BT": "Bhoutan",
+		"BV": "\u00EEle Bouvet",
+		"BY": "B\u00E9larus",
+		"CC": "Cocos (Keeling), \u00CEles",
+		"CD": "R\u00E9publique d\u00E9mocratique du Congo",
+		"CF": "R\u00E9publique centrafricaine",
+		"CG": "R\u00E9publique du Congo",
+		"CH": "Suisse",
+		"CK": "\u00EEles Cook",
+		"CL": "Chili",
+		"CM": "Cameroun",
+		"CN": "Chine",
+		"CO": "Colombie",
+		"CV": "Cap-Vert",
+		"CX": "Christmas, \u00CEle",
+		"CY": "Chypre",
+		"CZ": "Tch\u00E9quie",
+		"DE": "Allemagne",
+		"DK": "Danemark",
+		"DM": "Dominique",
+		"DO": "R\u00E9publique dominicaine",
+		"DZ": "Alg\u00E9rie",
+		"EC": "\u00C9quateur",
+		"EE": "Estonie",
+		"EG": "\u00C9gypte",
+		"EH": "Sahara occidental",
+		"ER": "\u00C9rythr\u00E9e",
+		"ES": "Espagne",
+		"ET": "\u00C9thiopie",
+		"FI": "Finlande",
+		"FJ": "Fidji",
+		"FK": "Malouines, \u00CEles (Falkland)",
+		"FM": "Micron\u00E9sie, \u00C9tats f\u00E9d\u00E9r\u00E9s de",
+		"FO": "\u00EEles F\u00E9ro\u00E9",
+		"GB": "Royaume-Uni",
+		"GD": "Grenade",
+		"GE": "G\u00E9orgie",
+		"GF": "Guyane fran\u00E7aise",
+		"GG": "Guernesey",
+		"GL": "Gro\u00EBnland",
+		"GM": "Gambie",
+		"GN": "Guin\u00E9e",
+		"GQ": "Guin\u00E9e \u00C9quatoriale",
+		"GR": "Gr\u00E8ce",
+		"GS": "G\u00E9orgie du Sud et les \u00EEles Sandwich du Sud",
+		"GW": "Guin\u00E9e-Bissau",
+		"HM": "\u00EEles Heard-et-MacDonald",
+		"HR": "Croatie",
+		"HT": "Ha\u00EFti",
+		"HU": "Hongrie",
+		"ID": "Indon\u00E9sie",
+		"IE": "Irlande",
+		"IL": "Isra\u00EBl",
+		"IM": "\u00CEle de Man",
+		"IN": "Inde",
+		"IO": "Territoire britannique de l'oc\u00E9an Indien",
+		"IQ": "Irak",
+		"IR": "Iran, R\u00E9publique islamique d'",
+		"IS": "Islande",
+		"IT": "Italie",
+		"JM": "Jama\u00EFque",
+		"JO": "Jordanie",
+		"JP": "Japon",
+		"KG": "Kirghizistan",
+		"KH": "Cambodge",
+		"KM": "Comores",
+		"KN": "Saint-Christophe-et-Ni\u00E9v\u00E8s",
+		"KP": "Cor\u00E9e, R\u00E9publique populaire d\u00E9mocratique de",
+		"KR": "Cor\u00E9e, R\u00E9publique de",
+		"KW": "Kowe\u00EFt",
+		"KY": "\u00EEles Ca\u00EFmans",
+		"LA": "Lao, R\u00E9publique d\u00E9mocratique populaire",
+		"LB": "Liban",
+		"LC": "Sainte-Lucie",
+		"LR": "Lib\u00E9ria",
+		"LT": "Lituanie",
+		"LV": "Lettonie",
+		"LY": "Libye",
+		"MA": "Maroc",
+		"MD": "Moldova, R\u00E9publique de",
+		"ME": "Mont\u00E9n\u00E9gro",
+		"MF": "Saint-Martin (partie fran\u00E7aise)",
+		"MH": "\u00CEles Marshall",
+		"MK": "Mac\u00E9doine du Nord",
+		"MM": "Birmanie",
+		"MN": "Mongolie",
+		"MO": "Macau",
+		"MP": "\u00CEles Mariannes du Nord",
+		"MR": "Mauritanie",
+		"MT": "Malte",
+		"MU": "Maurice",
+		"MX": "Mexique",
+		"MY": "Malaisie",
+		"NA": "Namibie",
+		"NC": "Nouvelle-Cal\u00E9donie",
+		"NF": "\u00EEle Norfolk",
+		"NL": "Pays-Bas",
+		"NO": "Norv\u00E8ge",
+		"NP": "N\u00E9pal",
+		"NU": "Nioue",
+		"NZ": "Nouvelle-Z\u00E9lande",
+		"PE": "P\u00E9rou",
+		"PF": "Polyn\u00E9sie fran\u00E7aise",
+		"PG": "Papouasie-Nouvelle-Guin\u00E9e",
+		"PL": "Pologne",
+		"PM": "Saint-Pierre-et-Miquelon",
+		"PN": "\u00CEles Pitcairn",
+		"PR": "Porto Rico",
+		"PS": "Palestine, \u00C9tat de",
+		"PW": "Palaos",
+		"RE": "R\u00E9union, \u00CEle de la",
+		"RO": "Roumanie",
+		"RS": "Serbie",
+		"RU": "Russie, F\u00E9d\u00E9ration de",
+		"SA": "Arabie saoudite",
+		"SB": "Salomon, \u00CEles",
+		"SD": "Soudan",
+		"SE": "Su\u00E8de",
+		"SG": "Singapour",
+		"SH": "Sainte-H\u00E9l\u00E8ne, Ascension et Tristan da Cunha",
+		"SI": "Slov\u00E9nie",
+		"SJ": "Svalbard et \u00EEle Jan Mayen",
+		"SK": "Slovaquie",
+		"SM": "Saint-Marin",
+		"SN": "S\u00E9n\u00E9gal",
+		"SO": "Somalie",
+		"SR": "Surinam",
+		"SS": "Soudan du Sud",
+		"ST": "Sao Tom\u00E9-et-Principe",
+		"SV": "Salvador",
+		"SX": "Saint-Martin (partie n\u00E9erlandaise)",
+		"SY": "Syrienne, R\u00E9publique arabe",
+		"SZ": "Eswatini",
+		"TC": "\u00EEles Turques-et-Ca\u00EFques",
+		"TD": "Tchad",
+		"TF": "Terres australes fran\u00E7aises",
+		"TH": "Tha\u00EFlande",
+		"TJ": "Tadjikistan",
+		"TL": "Timor oriental",
+		"TM": "Turkm\u00E9nistan",
+		"TN": "Tunisie",
+		"TR": "T\u00FCrkiye",
+		"TT": "Trinit\u00E9-et-Tobago",
+		"TW": "Ta\u00EFwan, province de Chine",
+		"TZ": "Tanzanie, R\u00E9publique unie de",
+		"UG": "Ouganda",
+		"UM": "\u00CEles mineures \u00E9loign\u00E9es des \u00C9tats-Unis",
+		"US": "\u00C9tats-Unis",
+		"UZ": "Ouzb\u00E9kistan",
+		"VA": "Saint-Si\u00E8ge (\u00E9tat de la cit\u00E9 du Vatican)",
+		"VC": "Saint-Vincent-et-les-Grenadines",
+		"VE": "V\u00E9n\u00E9zuela, r\u00E9publique bolivarienne du",
+		"VG": "\u00CEles Vierges britanniques",
+		"VI": "\u00CEles Vierges, \u00C9tats-Unis",
+		"VN": "Vi\u00EAt Nam",
+		"WF": "Wallis et Futuna",
+		"YE": "Y\u00E9men",
+		"ZA": "Afrique du Sud",
+		"ZM": "Zambie",
+	},
+}
+
+// LocalizedName returns the country's name in the given language, an ISO
+// 639-1 code matched case-insensitively. Only French ("fr"), Spanish ("es")
+// and German ("de") are available; other languages, and entries without a
+// translation, fall back to Name.
+func (c CountryCode) LocalizedName(lang string) string {
+	if name, ok := localized_names[strings.ToLower(lang)][c.Alpha2]; ok {
+		return name
+	}
+
+	return c.Name
+}
+
+// GetByLocalizedName returns the entry whose LocalizedName in the given
+// language is name, ignoring case and diacritics, preferring officially
+// assigned entries where names are shared.
+func GetByLocalizedName(lang, name string) (CountryCode, bool) {
+	key := foldDiacritics(strings.ToLower(strings.TrimSpace(name)))
+	if key == "" {
+		return CountryCode{}, false
+	}
+
+	var match CountryCode
+	for _, cc := range All() {
+		n := foldDiacritics(strings.ToLower(cc.LocalizedName(lang)))
+		if n == key && (match.IsZero() || cc.Assignment == OFFICIALLY_ASSIGNED && match.Assignment != OFFICIALLY_ASSIGNED) {
+			match = cc
+		}
+	}
+
+	return match, !match.IsZero()
+}
@@ -0,0 +1,136 @@
+package countrycodes
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// ErrNotFound is wrapped by the errors the Lookup functions return when no
+// entry matches, so callers can test for it with errors.Is.
+var ErrNotFound = errors.New("countrycodes: country not found")
+
+// LookupByAlpha2 is GetByAlpha2 returning ErrNotFound instead of false.
+func LookupByAlpha2(a2 string) (CountryCode, error) {
+	code, ok := GetByAlpha2(a2)
+	if !ok {
+		return code, fmt.Errorf("%w: alpha-2 %q", ErrNotFound, a2)
+	}
+
+	return code, nil
+}
+
+// LookupByAlpha3 is GetByAlpha3 returning ErrNotFound instead of false.
+func LookupByAlpha3(a3 string) (CountryCode, error) {
+	code, ok := GetByAlpha3(a3)
+	if !ok {
+		return code, fmt.Errorf("%w: alpha-3 %q", ErrNotFound, a3)
+	}
+
+	return code, nil
+}
+
+// LookupByName is GetByName returning ErrNotFound instead of false.
+func LookupByName(name string) (CountryCode, error) {
+	code, ok := GetByName(name)
+	if !ok {
+		return code, fmt.Errorf("%w: name %q", ErrNotFound, name)
+	}
+
+	return code, nil
+}
+
+// LookupByNumeric is GetByNumeric returning ErrNotFound instead of false.
+func LookupByNumeric(numeric int) (CountryCode, error) {
+	code, ok := GetByNumeric(numeric)
+	if !ok {
+		return code, fmt.Errorf("%w: numeric %d", ErrNotFound, numeric)
+	}
+
+	return code, nil
+}
+
+// GetManyByAlpha2 looks up each code, ignoring case and surrounding
+// whitespace, and returns the entries found in input order along with the
+// codes, as given, that did not resolve.
+func GetManyByAlpha2(codes []string) ([]CountryCode, []string) {
+	found := make([]CountryCode, 0, len(codes))
+	var missing []string
+
+	for _, a2 := range codes {
+		if code, ok := GetByAlpha2(strings.ToUpper(strings.TrimSpace(a2))); ok {
+			found = append(found, code)
+		} else {
+			missing = append(missing, a2)
+		}
+	}
+
+	return found, missing
+}
+
+// RandomOfficial returns an officially assigned entry chosen uniformly using
+// r, so a seeded source gives the same entry on every run.
+func RandomOfficial(r *rand.Rand) CountryCode {
+	official := make([]CountryCode, 0, len(by_alpha2))
+
+	for _, cc := range All() {
+		if cc.IsOfficiallyAssigned() {
+			official = append(official, cc)
+		}
+	}
+
+	return official[r.Intn(len(official))]
+}
+
+// GetByAny resolves an identifier of unknown kind. Ignoring surrounding
+// whitespace, it tries in order and returns the first hit of:
+//
+//  1. an alpha-2 code, ignoring case;
+//  2. an alpha-3 code, ignoring case;
+//  3. a numeric code, as accepted by GetByNumericString;
+//  4. an exact name, as accepted by GetByName;
+//  5. a common name or alias, as accepted by GetByCommonName.
+func GetByAny(s string) (CountryCode, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return CountryCode{}, false
+	}
+
+	upper := strings.ToUpper(s)
+
+	if code, ok := GetByAlpha2(upper); ok {
+		return code, true
+	}
+
+	if code, ok := GetByAlpha3(upper); ok {
+		return code, true
+	}
+
+	if code, ok := GetByNumericString(s); ok {
+		return code, true
+	}
+
+	if code, ok := GetByName(s); ok {
+		return code, true
+	}
+
+	return GetByCommonName(s)
+}
+
+// LookupByAlpha2Suggest is LookupByAlpha2 with a suggestion in the error,
+// e.g. `unknown code "GRB"; did you mean "GB"?`, when an alpha-2 or alpha-3
+// code is within one edit of a2. The suggestion is always an alpha-2 code. The error still wraps ErrNotFound. Finding the suggestion scans
+// the dataset, so hot paths should call LookupByAlpha2 instead.
+func LookupByAlpha2Suggest(a2 string) (CountryCode, error) {
+	code, ok := GetByAlpha2(a2)
+	if ok {
+		return code, nil
+	}
+
+	if suggestion := suggestCode(a2); suggestion != "" {
+		return code, fmt.Errorf("%w: unknown code %q; did you mean %q?", ErrNotFound, a2, suggestion)
+	}
+
+	return code, fmt.Errorf("%w: unknown code %q", ErrNotFound, a2)
+}
@@ -0,0 +1,223 @@
+package countrycodes
+
+import (
+	"strings"
+)
+
+// NameStyle selects which form of a country's name DisplayName returns.
+type NameStyle int
+
+const (
+	// NameISO is the name exactly as stored in Name, e.g. "Korea, Republic of".
+	NameISO NameStyle = iota
+
+	// NameCommon is the short form used in everyday speech, e.g. "South Korea".
+	NameCommon
+
+	// NameOfficial is the long formal name, e.g. "Republic of Korea".
+	NameOfficial
+
+	// NameNatural is the ISO name in natural reading order, e.g. "Republic
+	// of Korea" for "Korea, Republic of". See naturalName for the rules.
+	NameNatural
+)
+
+// common_names holds the everyday short names for entries whose ISO name is
+// inverted, formal or otherwise not what users expect to read.
+var common_names = map[string]string{
+	"BN": "Brunei",
+	"BO": "Bolivia",
+	"CC": "Cocos Islands",
+	"CD": "DR Congo",
+	"CI": "Ivory Coast",
+	"CZ": "Czechia",
+	"FK": "Falkland Islands",
+	"FM": "Micronesia",
+	"IR": "Iran",
+	"KP": "North Korea",
+	"KR": "South Korea",
+	"LA": "Laos",
+	"MD": "Moldova",
+	"MF": "Saint Martin",
+	"MK": "North Macedonia",
+	"PS": "Palestine",
+	"RU": "Russia",
+	"SH": "Saint Helena",
+	"SX": "Sint Maarten",
+	"SY": "Syria",
+	"SZ": "Eswatini",
+	"TW": "Taiwan",
+	"TZ": "Tanzania",
+	"VA": "Vatican City",
+	"VE": "Venezuela",
+	"VG": "British Virgin Islands",
+	"VI": "U.S. Virgin Islands",
+	"VN": "Vietnam",
+	"XK": "Kosovo",
+}
+
+// official_names holds the long formal names for entries where they differ
+// from the ISO name in a way UIs care about.
+var official_names = map[string]string{
+	"BO": "Plurinational State of Bolivia",
+	"CD": "Democratic Republic of the Congo",
+	"CG": "Republic of the Congo",
+	"CH": "Swiss Confederation",
+	"CN": "People's Republic of China",
+	"DE": "Federal Republic of Germany",
+	"FM": "Federated States of Micronesia",
+	"FR": "French Republic",
+	"GB": "United Kingdom of Great Britain and Northern Ireland",
+	"IR": "Islamic Republic of Iran",
+	"KP": "Democratic People's Republic of Korea",
+	"KR": "Republic of Korea",
+	"MD": "Republic of Moldova",
+	"MK": "Republic of North Macedonia",
+	"MX": "United Mexican States",
+	"NL": "Kingdom of the Netherlands",
+	"PS": "State of Palestine",
+	"TZ": "United Republic of Tanzania",
+	"US": "United States of America",
+	"VA": "Holy See",
+	"VE": "Bolivarian Republic of Venezuela",
+	"VG": "British Virgin Islands",
+	"VI": "Virgin Islands of the United States",
+	"VN": "Socialist Republic of Viet Nam",
+	"XK": "Republic of Kosovo",
+}
+
+// name_aliases maps lower-cased names people commonly type to the entry
+// they mean, where neither the ISO name nor a curated display name matches.
+var name_aliases = map[string]string{
+	"america":                  "US",
+	"britain":                  "GB",
+	"burma":                    "MM",
+	"cape verde":               "CV",
+	"czech republic":           "CZ",
+	"east timor":               "TL",
+	"great britain":            "GB",
+	"holland":                  "NL",
+	"macedonia":                "MK",
+	"swaziland":                "SZ",
+	"the netherlands":          "NL",
+	"turkey":                   "TR",
+	"u.k.":                     "GB",
+	"u.s.":                     "US",
+	"u.s.a.":                   "US",
+	"uk":                       "GB",
+	"united states of america": "US",
+	"us":                       "US",
+	"usa":                      "US",
+	"vatican":                  "VA",
+}
+
+// GetByCommonName returns the entry a free-text country name refers to,
+// ignoring case, diacritics and a leading or trailing "the". It tries, in
+// order, the alias table, the common and official display names and the ISO
+// names, preferring officially assigned entries, so "South Korea", "USA" and
+// "viet nam" all resolve.
+func GetByCommonName(name string) (CountryCode, bool) {
+	key := foldDiacritics(strings.ToLower(strings.TrimSpace(name)))
+	if key == "" {
+		return CountryCode{}, false
+	}
+
+	if a2, ok := name_aliases[key]; ok {
+		return GetByAlpha2(a2)
+	}
+
+	stripped := stripArticle(key)
+
+	for _, names := range []map[string]string{common_names, official_names} {
+		for a2, n := range names {
+			n = foldDiacritics(strings.ToLower(n))
+			if n == key || n == stripped {
+				return GetByAlpha2(a2)
+			}
+		}
+	}
+
+	var match CountryCode
+	for _, cc := range All() {
+		n := foldDiacritics(strings.ToLower(cc.Name))
+		if (n == key || n == stripped) && (match.IsZero() || cc.Assignment == OFFICIALLY_ASSIGNED && match.Assignment != OFFICIALLY_ASSIGNED) {
+			match = cc
+		}
+	}
+
+	return match, !match.IsZero()
+}
+
+// DisplayName returns the country's name in the requested style, falling
+// back to the ISO name when no curated alternative exists.
+func (c CountryCode) DisplayName(style NameStyle) string {
+	if style == NameNatural {
+		return naturalName(c.Name)
+	}
+
+	var names map[string]string
+
+	switch style {
+	case NameCommon:
+		names = common_names
+	case NameOfficial:
+		names = official_names
+	}
+
+	if name, ok := names[c.Alpha2]; ok {
+		return name
+	}
+
+	return c.Name
+}
+
+// Label returns the country as "Name (AA)", e.g. "Germany (DE)", or an
+// empty string for the zero CountryCode.
+func (c CountryCode) Label() string {
+	if c.IsZero() {
+		return ""
+	}
+
+	return c.Name + " (" + c.Alpha2 + ")"
+}
+
+// String implements fmt.Stringer, returning Label.
+func (c CountryCode) String() string {
+	return c.Label()
+}
+
+// inverted_adjectives are the one-word tails that mark an inverted ISO name
+// such as "Virgin Islands, British". A one-word tail alone is not enough:
+// "Ceuta, Melilla" is a list.
+var inverted_adjectives = map[string]bool{
+	"British":      true,
+	"Metropolitan": true,
+	"U.S.":         true,
+}
+
+// naturalName turns an inverted ISO name into natural reading order. Only
+// names with exactly one comma whose tail ends in "of" or "of the", or is one
+// of inverted_adjectives, are inverted ones; the tail is moved to the front,
+// a leading "the" is dropped and its first letter capitalized:
+//
+//	"Korea, Republic of"                    -> "Republic of Korea"
+//	"Congo, the Democratic Republic of the" -> "Democratic Republic of the Congo"
+//	"Virgin Islands, British"               -> "British Virgin Islands"
+//
+// Every other name, including lists such as "Bonaire, Sint Eustatius and
+// Saba" and "Ceuta, Melilla", is returned unchanged.
+func naturalName(name string) string {
+	parts := strings.Split(name, ", ")
+	if len(parts) != 2 {
+		return name
+	}
+
+	head, tail := parts[0], parts[1]
+	if !strings.HasSuffix(tail, " of") && !strings.HasSuffix(tail, " of the") && !inverted_adjectives[tail] {
+		return name
+	}
+
+	tail = strings.TrimPrefix(tail, "the ")
+
+	return strings.ToUpper(tail[:1]) + tail[1:] + " " + head
+}
@@ -0,0 +1,29 @@
+package countrycodes
+
+import (
+	"strings"
+)
+
+// alpha2_aliases maps non-standard alpha-2 codes in common use to their
+// ISO 3166-1 equivalent. This is the complete table:
+//
+//	UK -> GB  United Kingdom; UK is exceptionally reserved for it
+//	EL -> GR  Greece, as used by the European Union
+var alpha2_aliases = map[string]string{
+	"UK": "GB",
+	"EL": "GR",
+}
+
+// Normalize returns s upper-cased and trimmed, replaced by its ISO
+// equivalent if it is one of the non-standard codes in alpha2_aliases, and
+// whether such a replacement was made. It does not check that the result is
+// in the dataset; pass it to GetByAlpha2 for that.
+func Normalize(a2 string) (string, bool) {
+	a2 = strings.ToUpper(strings.TrimSpace(a2))
+
+	if official, ok := alpha2_aliases[a2]; ok {
+		return official, true
+	}
+
+	return a2, false
+}
@@ -0,0 +1,184 @@
+package countrycodes
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// override_columns are the columns LoadOverrides accepts, matching the
+// header WriteCSV writes.
+var override_columns = map[string]bool{
+	"alpha2":       true,
+	"alpha3":       true,
+	"numeric":      true,
+	"name":         true,
+	"dialing_code": true,
+	"assignment":   true,
+}
+
+// LoadOverrides patches existing entries from CSV read from r. The first row
+// is a header naming any of the columns WriteCSV writes; alpha2 is required
+// and selects the entry to patch, so it cannot itself be changed. Empty
+// cells leave the field unchanged, and assignment takes any spelling
+// ParseAssignment accepts.
+//
+// Cells holding the entry's current value are accepted as they are, so the
+// output of WriteCSV loads unchanged, including the four-letter ISO 3166-3
+// codes and -1 numeric placeholders of reserved entries. Every row is
+// validated before anything is changed: if any row names an unknown or
+// repeated alpha-2 code, changes a code to a malformed value such as an
+// alpha-3 code that is not three letters or a numeric code outside 1 to 999,
+// or gives an alpha-3 or numeric code belonging to a different entry or
+// claimed by another row, no entries are patched and the error lists each
+// failing row by line number.
+//
+// Like Register, LoadOverrides rebuilds the indexes under registry_mu, which
+// lookups do not take, so it must not run at the same time as any lookup,
+// search or enumeration.
+func LoadOverrides(r io.Reader) error {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return fmt.Errorf("countrycodes: reading overrides: %v", err)
+	}
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	header := records[0]
+	column := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if !override_columns[name] {
+			return fmt.Errorf("countrycodes: unknown override column %q", name)
+		}
+		column[name] = i
+	}
+
+	if _, ok := column["alpha2"]; !ok {
+		return fmt.Errorf("countrycodes: overrides have no alpha2 column")
+	}
+
+	registry_mu.Lock()
+	defer registry_mu.Unlock()
+	ensureIndexes()
+
+	patched := make([]CountryCode, 0, len(records)-1)
+	seen := make(map[string]bool)
+	alpha3s := make(map[string]string)
+	numerics := make(map[int]string)
+	var failures []string
+
+	for i, rec := range records[1:] {
+		line := i + 2
+
+		cc, err := applyOverride(rec, column)
+		old := by_alpha2[cc.Alpha2]
+		if err == nil {
+			switch {
+			case seen[cc.Alpha2]:
+				err = fmt.Errorf("%s is overridden more than once", cc.Alpha2)
+			case cc.Alpha3 != old.Alpha3 && alpha3s[cc.Alpha3] != "":
+				err = fmt.Errorf("alpha-3 %s is also given to %s", cc.Alpha3, alpha3s[cc.Alpha3])
+			case cc.Numeric != old.Numeric && numerics[cc.Numeric] != "":
+				err = fmt.Errorf("numeric %d is also given to %s", cc.Numeric, numerics[cc.Numeric])
+			}
+		}
+
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+
+		// Codes a row keeps are already in the live indexes, where
+		// applyOverride checks other rows against them, so only the codes
+		// it changes need tracking here.
+		seen[cc.Alpha2] = true
+		if cc.Alpha3 != old.Alpha3 {
+			alpha3s[cc.Alpha3] = cc.Alpha2
+		}
+		if cc.Numeric != old.Numeric {
+			numerics[cc.Numeric] = cc.Alpha2
+		}
+		patched = append(patched, cc)
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("countrycodes: %d override rows failed: %s", len(failures), strings.Join(failures, "; "))
+	}
+
+	for _, cc := range patched {
+		by_alpha2[cc.Alpha2] = cc
+	}
+
+	// Rebuild rather than patch the indexes, so that entries sharing a key
+	// with a patched one, such as SF with FI, win it back when it changes.
+	buildIndexes()
+
+	return nil
+}
+
+// applyOverride returns the entry named by rec's alpha2 cell with the rest
+// of rec's non-empty cells applied.
+func applyOverride(rec []string, column map[string]int) (CountryCode, error) {
+	cell := func(name string) string {
+		if i, ok := column[name]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+
+	a2 := cell("alpha2")
+	cc, ok := by_alpha2[a2]
+	if !ok {
+		return cc, fmt.Errorf("unknown alpha-2 %q", a2)
+	}
+
+	if a3 := cell("alpha3"); a3 != "" && a3 != cc.Alpha3 {
+		if len(a3) != 3 || !isASCIILetters(a3) {
+			return cc, fmt.Errorf("malformed alpha-3 %q", a3)
+		}
+		a3 = strings.ToUpper(a3)
+		if other, ok := by_alpha3[a3]; ok && a3 != cc.Alpha3 && other.Alpha2 != a2 {
+			return cc, fmt.Errorf("alpha-3 %s is already assigned to %s", a3, other.Alpha2)
+		}
+		cc.Alpha3 = a3
+	}
+
+	if s := cell("numeric"); s != "" {
+		numeric, err := strconv.Atoi(s)
+		if err != nil {
+			return cc, fmt.Errorf("malformed numeric %q", s)
+		}
+		if numeric != cc.Numeric {
+			if numeric < 1 || numeric > 999 {
+				return cc, fmt.Errorf("malformed numeric %q", s)
+			}
+			if other, ok := by_numeric[numeric]; ok && other.Alpha2 != a2 {
+				return cc, fmt.Errorf("numeric %d is already assigned to %s", numeric, other.Alpha2)
+			}
+			cc.Numeric = numeric
+		}
+	}
+
+	if name := cell("name"); name != "" {
+		cc.Name = name
+	}
+
+	if dialing := cell("dialing_code"); dialing != "" {
+		cc.DialingCode = dialing
+	}
+
+	if s := cell("assignment"); s != "" {
+		assignment, err := ParseAssignment(s)
+		if err != nil {
+			return cc, err
+		}
+		cc.Assignment = assignment
+	}
+
+	return cc, nil
+}
@@ -0,0 +1,146 @@
+package countrycodes
+
+// region_members lists the officially assigned entries in each UN M49
+// region, using the finest grouping M49 defines for the country: the
+// intermediate regions of Sub-Saharan Africa and of Latin America and the
+// Caribbean, and the sub-regions everywhere else. Antarctica belongs to no
+// M49 region and is left out.
+var region_members = map[int][]string{
+	15: { // Northern Africa
+		"DZ", "EG", "EH", "LY", "MA", "SD", "TN",
+	},
+	14: { // Eastern Africa
+		"BI", "DJ", "ER", "ET", "IO", "KE", "KM", "MG", "MU", "MW", "MZ", "RE",
+		"RW", "SC", "SO", "SS", "TF", "TZ", "UG", "YT", "ZM", "ZW",
+	},
+	17: { // Middle Africa
+		"AO", "CD", "CF", "CG", "CM", "GA", "GQ", "ST", "TD",
+	},
+	18: { // Southern Africa
+		"BW", "LS", "NA", "SZ", "ZA",
+	},
+	11: { // Western Africa
+		"BF", "BJ", "CI", "CV", "GH", "GM", "GN", "GW", "LR", "ML", "MR", "NE",
+		"NG", "SH", "SL", "SN", "TG",
+	},
+	29: { // Caribbean
+		"AG", "AI", "AW", "BB", "BL", "BQ", "BS", "CU", "CW", "DM", "DO", "GD",
+		"GP", "HT", "JM", "KN", "KY", "LC", "MF", "MQ", "MS", "PR", "SX", "TC",
+		"TT", "VC", "VG", "VI",
+	},
+	13: { // Central America
+		"BZ", "CR", "GT", "HN", "MX", "NI", "PA", "SV",
+	},
+	5: { // South America
+		"AR", "BO", "BR", "BV", "CL", "CO", "EC", "FK", "GF", "GS", "GY", "PE",
+		"PY", "SR", "UY", "VE",
+	},
+	21: { // Northern America
+		"BM", "CA", "GL", "PM", "US",
+	},
+	143: { // Central Asia
+		"KG", "KZ", "TJ", "TM", "UZ",
+	},
+	30: { // Eastern Asia
+		"CN", "HK", "JP", "KP", "KR", "MN", "MO", "TW",
+	},
+	35: { // South-eastern Asia
+		"BN", "ID", "KH", "LA", "MM", "MY", "PH", "SG", "TH", "TL", "VN",
+	},
+	34: { // Southern Asia
+		"AF", "BD", "BT", "IN", "IR", "LK", "MV", "NP", "PK",
+	},
+	145: { // Western Asia
+		"AE", "AM", "AZ", "BH", "CY", "GE", "IL", "IQ", "JO", "KW", "LB", "OM",
+		"PS", "QA", "SA", "SY", "TR", "YE",
+	},
+	151: { // Eastern Europe
+		"BG", "BY", "CZ", "HU", "MD", "PL", "RO", "RU", "SK", "UA",
+	},
+	154: { // Northern Europe
+		"AX", "DK", "EE", "FI", "FO", "GB", "GG", "IE", "IM", "IS", "JE", "LT",
+		"LV", "NO", "SE", "SJ",
+	},
+	39: { // Southern Europe
+		"AD", "AL", "BA", "ES", "GI", "GR", "HR", "IT", "ME", "MK", "MT", "PT",
+		"RS", "SI", "SM", "VA",
+	},
+	155: { // Western Europe
+		"AT", "BE", "CH", "DE", "FR", "LI", "LU", "MC", "NL",
+	},
+	53: { // Australia and New Zealand
+		"AU", "CC", "CX", "HM", "NF", "NZ",
+	},
+	54: { // Melanesia
+		"FJ", "NC", "PG", "SB", "VU",
+	},
+	57: { // Micronesia
+		"FM", "GU", "KI", "MH", "MP", "NR", "PW", "UM",
+	},
+	61: { // Polynesia
+		"AS", "CK", "NU", "PF", "PN", "TK", "TO", "TV", "WF", "WS",
+	},
+}
+
+// region_parents maps each M49 region to the region containing it, up to
+// 001 (World).
+var region_parents = map[int]int{
+	2:   1,   // Africa
+	9:   1,   // Oceania
+	19:  1,   // Americas
+	142: 1,   // Asia
+	150: 1,   // Europe
+	15:  2,   // Northern Africa
+	202: 2,   // Sub-Saharan Africa
+	11:  202, // Western Africa
+	14:  202, // Eastern Africa
+	17:  202, // Middle Africa
+	18:  202, // Southern Africa
+	21:  19,  // Northern America
+	419: 19,  // Latin America and the Caribbean
+	5:   419, // South America
+	13:  419, // Central America
+	29:  419, // Caribbean
+	30:  142, // Eastern Asia
+	34:  142, // Southern Asia
+	35:  142, // South-eastern Asia
+	143: 142, // Central Asia
+	145: 142, // Western Asia
+	39:  150, // Southern Europe
+	151: 150, // Eastern Europe
+	154: 150, // Northern Europe
+	155: 150, // Western Europe
+	53:  9,   // Australia and New Zealand
+	54:  9,   // Melanesia
+	57:  9,   // Micronesia
+	61:  9,   // Polynesia
+}
+
+// applyRegionCodes sets RegionCode on every entry in region_members.
+func applyRegionCodes() {
+	for region, members := range region_members {
+		for _, a2 := range members {
+			cc := by_alpha2[a2]
+			cc.RegionCode = region
+			by_alpha2[a2] = cc
+		}
+	}
+}
+
+// AllByRegionCode returns the entries in the given UN M49 region, sorted by
+// alpha-2. Any level of the hierarchy can be used: 155 (Western Europe),
+// 150 (Europe) and 1 (World) all include DE.
+func AllByRegionCode(region int) []CountryCode {
+	codes := make([]CountryCode, 0)
+
+	for _, cc := range All() {
+		for r := cc.RegionCode; r != 0; r = region_parents[r] {
+			if r == region {
+				codes = append(codes, cc)
+				break
+			}
+		}
+	}
+
+	return codes
+}
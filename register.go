@@ -0,0 +1,49 @@
+package countrycodes
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// registry_mu serializes changes to the dataset. Lookups do not take it.
+var registry_mu sync.Mutex
+
+// Register adds a custom entry, such as a private-use code from the ISO
+// user-assigned range (AA, QM-QZ, XA-XZ, ZZ), to the dataset and rebuilds
+// its indexes. It may replace an existing USER_ASSIGNED entry with the same
+// alpha-2 code, but returns an error if the alpha-2 code is malformed or
+// belongs to any other entry, or if the alpha-3 or positive numeric code
+// belongs to a different entry.
+//
+// Register rebuilds the indexes under registry_mu, which lookups do not
+// take, so it is safe to call from multiple goroutines but must not run at
+// the same time as any lookup, search or enumeration: call it during program
+// start-up, before the dataset is read concurrently.
+func Register(c CountryCode) error {
+	if len(c.Alpha2) != 2 || strings.ToUpper(c.Alpha2) != c.Alpha2 || !isASCIILetters(c.Alpha2) {
+		return fmt.Errorf("countrycodes: malformed alpha-2 code %q", c.Alpha2)
+	}
+
+	registry_mu.Lock()
+	defer registry_mu.Unlock()
+	ensureIndexes()
+
+	old, exists := by_alpha2[c.Alpha2]
+	if exists && old.Assignment != USER_ASSIGNED {
+		return fmt.Errorf("countrycodes: %s is already assigned to %s", c.Alpha2, old.Name)
+	}
+
+	if other, ok := by_alpha3[c.Alpha3]; ok && other.Alpha2 != c.Alpha2 {
+		return fmt.Errorf("countrycodes: alpha-3 %s is already assigned to %s", c.Alpha3, other.Alpha2)
+	}
+
+	if other, ok := by_numeric[c.Numeric]; ok && c.Numeric > 0 && other.Alpha2 != c.Alpha2 {
+		return fmt.Errorf("countrycodes: numeric %d is already assigned to %s", c.Numeric, other.Alpha2)
+	}
+
+	by_alpha2[c.Alpha2] = c
+	buildIndexes()
+
+	return nil
+}
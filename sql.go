@@ -0,0 +1,93 @@
+package countrycodes
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Value implements driver.Valuer, storing the country as its alpha-2 code.
+// The zero CountryCode is stored as NULL.
+func (c CountryCode) Value() (driver.Value, error) {
+	if c.Alpha2 == "" {
+		return nil, nil
+	}
+
+	return c.Alpha2, nil
+}
+
+// Scan implements sql.Scanner, so one CountryCode field works whichever code
+// a column stores. The source is resolved by its type and shape:
+//
+//   - an int64 is a numeric code, e.g. 840;
+//   - a string or []byte is trimmed, then two letters are an alpha-2 code,
+//     three letters an alpha-3 code and one to three digits a numeric code,
+//     so "US", "USA" and "840" all scan as the United States. Letter codes
+//     ignore case, so "us" and "usa" do too.
+//
+// NULL and empty values leave the zero CountryCode. Any other text, such as a
+// name or a code of the wrong length, is ambiguous and returns an error, and
+// an unknown code returns an error wrapping ErrNotFound.
+func (c *CountryCode) Scan(src interface{}) error {
+	var s string
+
+	switch v := src.(type) {
+	case nil:
+		*c = CountryCode{}
+		return nil
+	case int64:
+		return c.scanNumeric(v)
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("countrycodes: cannot scan %T into CountryCode", src)
+	}
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		*c = CountryCode{}
+		return nil
+	}
+
+	var code CountryCode
+	var err error
+
+	switch ClassifyInput(s) {
+	case KindAlpha2:
+		code, err = LookupByAlpha2(strings.ToUpper(s))
+	case KindAlpha3:
+		code, err = LookupByAlpha3(s)
+	case KindNumeric:
+		n, _ := strconv.ParseInt(s, 10, 64)
+		return c.scanNumeric(n)
+	default:
+		return fmt.Errorf("countrycodes: cannot scan %q: not an alpha-2, alpha-3 or numeric code", s)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*c = code
+
+	return nil
+}
+
+// scanNumeric fills c from a numeric code read by Scan.
+func (c *CountryCode) scanNumeric(n int64) error {
+	if n <= 0 || n > 999 {
+		return fmt.Errorf("%w: numeric %d", ErrNotFound, n)
+	}
+
+	code, err := LookupByNumeric(int(n))
+	if err != nil {
+		return err
+	}
+
+	*c = code
+
+	return nil
+}
@@ -0,0 +1,372 @@
+package countrycodes
+
+import (
+	"strings"
+)
+
+// subdivisions holds the ISO 3166-2 subdivision codes and English names for
+// a handful of high-traffic countries, keyed by country alpha-2 and then by
+// full subdivision code, e.g. "US-CA". Coverage is partial: countries not
+// listed here have no subdivision data, not no subdivisions. Every level
+// ISO publishes is included, so GB lists its four countries alongside its
+// counties, council areas and boroughs.
+var subdivisions = map[string]map[string]string{
+	"AU": {
+		"AU-ACT": "Australian Capital Territory",
+		"AU-NSW": "New South Wales",
+		"AU-NT":  "Northern Territory",
+		"AU-QLD": "Queensland",
+		"AU-SA":  "South Australia",
+		"AU-TAS": "Tasmania",
+		"AU-VIC": "Victoria",
+		"AU-WA":  "Western Australia",
+	},
+	"CA": {
+		"CA-AB": "Alberta",
+		"CA-BC": "British Columbia",
+		"CA-MB": "Manitoba",
+		"CA-NB": "New Brunswick",
+		"CA-NL": "Newfoundland and Labrador",
+		"CA-NS": "Nova Scotia",
+		"CA-NT": "Northwest Territories",
+		"CA-NU": "Nunavut",
+		"CA-ON": "Ontario",
+		"CA-PE": "Prince Edward Island",
+		"CA-QC": "Quebec",
+		"CA-SK": "Saskatchewan",
+		"CA-YT": "Yukon",
+	},
+	"DE": {
+		"DE-BB": "Brandenburg",
+		"DE-BE": "Berlin",
+		"DE-BW": "Baden-W\u00FCrttemberg",
+		"DE-BY": "Bayern",
+		"DE-HB": "Bremen",
+		"DE-HE": "Hessen",
+		"DE-HH": "Hamburg",
+		"DE-MV": "Mecklenburg-Vorpommern",
+		"DE-NI": "Niedersachsen",
+		"DE-NW": "Nordrhein-Westfalen",
+		"DE-RP": "Rheinland-Pfalz",
+		"DE-SH": "Schleswig-Holstein",
+		"DE-SL": "Saarland",
+		"DE-SN": "Sachsen",
+		"DE-ST": "Sachsen-Anhalt",
+		"DE-TH": "Th\u00FCringen",
+	},
+	"GB": {
+		"GB-ABC": "Armagh City, Banbridge and Craigavon",
+		"GB-ABD": "Aberdeenshire",
+		"GB-ABE": "Aberdeen City",
+		"GB-AGB": "Argyll and Bute",
+		"GB-AGY": "Isle of Anglesey [Sir Ynys M\u00F4n GB-YNM]",
+		"GB-AND": "Ards and North Down",
+		"GB-ANN": "Antrim and Newtownabbey",
+		"GB-ANS": "Angus",
+		"GB-BAS": "Bath and North East Somerset",
+		"GB-BBD": "Blackburn with Darwen",
+		"GB-BCP": "Bournemouth, Christchurch and Poole",
+		"GB-BDF": "Bedford",
+		"GB-BDG": "Barking and Dagenham",
+		"GB-BEN": "Brent",
+		"GB-BEX": "Bexley",
+		"GB-BFS": "Belfast City",
+		"GB-BGE": "Bridgend [Pen-y-bont ar Ogwr GB-POG]",
+		"GB-BGW": "Blaenau Gwent",
+		"GB-BIR": "Birmingham",
+		"GB-BKM": "Buckinghamshire",
+		"GB-BNE": "Barnet",
+		"GB-BNH": "Brighton and Hove",
+		"GB-BNS": "Barnsley",
+		"GB-BOL": "Bolton",
+		"GB-BPL": "Blackpool",
+		"GB-BRC": "Bracknell Forest",
+		"GB-BRD": "Bradford",
+		"GB-BRY": "Bromley",
+		"GB-BST": "Bristol, City of",
+		"GB-BUR": "Bury",
+		"GB-CAM": "Cambridgeshire",
+		"GB-CAY": "Caerphilly [Caerffili GB-CAF]",
+		"GB-CBF": "Central Bedfordshire",
+		"GB-CCG": "Causeway Coast and Glens",
+		"GB-CGN": "Ceredigion [Sir Ceredigion]",
+		"GB-CHE": "Cheshire East",
+		"GB-CHW": "Cheshire West and Chester",
+		"GB-CLD": "Calderdale",
+		"GB-CLK": "Clackmannanshire",
+		"GB-CMA": "Cumbria",
+		"GB-CMD": "Camden",
+		"GB-CMN": "Carmarthenshire [Sir Gaerfyrddin GB-GFY]",
+		"GB-CON": "Cornwall",
+		"GB-COV": "Coventry",
+		"GB-CRF": "Cardiff [Caerdydd GB-CRD]",
+		"GB-CRY": "Croydon",
+		"GB-CWY": "Conwy",
+		"GB-DAL": "Darlington",
+		"GB-DBY": "Derbyshire",
+		"GB-DEN": "Denbighshire [Sir Ddinbych GB-DDB]",
+		"GB-DER": "Derby",
+		"GB-DEV": "Devon",
+		"GB-DGY": "Dumfries and Galloway",
+		"GB-DNC": "Doncaster",
+		"GB-DND": "Dundee City",
+		"GB-DOR": "Dorset",
+		"GB-DRS": "Derry and Strabane",
+		"GB-DUD": "Dudley",
+		"GB-DUR": "Durham, County",
+		"GB-EAL": "Ealing",
+		"GB-EAY": "East Ayrshire",
+		"GB-EDH": "Edinburgh, City of",
+		"GB-EDU": "East Dunbartonshire",
+		"GB-ELN": "East Lothian",
+		"GB-ELS": "Eilean Siar",
+		"GB-ENF": "Enfield",
+		"GB-ENG": "England",
+		"GB-ERW": "East Renfrewshire",
+		"GB-ERY": "East Riding of Yorkshire",
+		"GB-ESS": "Essex",
+		"GB-ESX": "East Sussex",
+		"GB-FAL": "Falkirk",
+		"GB-FIF": "Fife",
+		"GB-FLN": "Flintshire [Sir y Fflint GB-FFL]",
+		"GB-FMO": "Fermanagh and Omagh",
+		"GB-GAT": "Gateshead",
+		"GB-GLG": "Glasgow City",
+		"GB-GLS": "Gloucestershire",
+		"GB-GRE": "Greenwich",
+		"GB-GWN": "Gwynedd",
+		"GB-HAL": "Halton",
+		"GB-HAM": "Hampshire",
+		"GB-HAV": "Havering",
+		"GB-HCK": "Hackney",
+		"GB-HEF": "Herefordshire",
+		"GB-HIL": "Hillingdon",
+		"GB-HLD": "Highland",
+		"GB-HMF": "Hammersmith and Fulham",
+		"GB-HNS": "Hounslow",
+		"GB-HPL": "Hartlepool",
+		"GB-HRT": "Hertfordshire",
+		"GB-HRW": "Harrow",
+		"GB-HRY": "Haringey",
+		"GB-IOS": "Isles of Scilly",
+		"GB-IOW": "Isle of Wight",
+		"GB-ISL": "Islington",
+		"GB-IVC": "Inverclyde",
+		"GB-KEC": "Kensington and Chelsea",
+		"GB-KEN": "Kent",
+		"GB-KHL": "Kingston upon Hull",
+		"GB-KIR": "Kirklees",
+		"GB-KTT": "Kingston upon Thames",
+		"GB-KWL": "Knowsley",
+		"GB-LAN": "Lancashire",
+		"GB-LBC": "Lisburn and Castlereagh",
+		"GB-LBH": "Lambeth",
+		"GB-LCE": "Leicester",
+		"GB-LDS": "Leeds",
+		"GB-LEC": "Leicestershire",
+		"GB-LEW": "Lewisham",
+		"GB-LIN": "Lincolnshire",
+		"GB-LIV": "Liverpool",
+		"GB-LND": "London, City of",
+		"GB-LUT": "Luton",
+		"GB-MAN": "Manchester",
+		"GB-MDB": "Middlesbrough",
+		"GB-MDW": "Medway",
+		"GB-MEA": "Mid and East Antrim",
+		"GB-MIK": "Milton Keynes",
+		"GB-MLN": "Midlothian",
+		"GB-MON": "Monmouthshire [Sir Fynwy GB-FYN]",
+		"GB-MRT": "Merton",
+		"GB-MRY": "Moray",
+		"GB-MTY": "Merthyr Tydfil [Merthyr Tudful GB-MTU]",
+		"GB-MUL": "Mid-Ulster",
+		"GB-NAY": "North Ayrshire",
+		"GB-NBL": "Northumberland",
+		"GB-NEL": "North East Lincolnshire",
+		"GB-NET": "Newcastle upon Tyne",
+		"GB-NFK": "Norfolk",
+		"GB-NGM": "Nottingham",
+		"GB-NIR": "Northern Ireland",
+		"GB-NLK": "North Lanarkshire",
+		"GB-NLN": "North Lincolnshire",
+		"GB-NMD": "Newry, Mourne and Down",
+		"GB-NSM": "North Somerset",
+		"GB-NTH": "Northamptonshire",
+		"GB-NTL": "Neath Port Talbot [Castell-nedd Port Talbot GB-CTL]",
+		"GB-NTT": "Nottinghamshire",
+		"GB-NTY": "North Tyneside",
+		"GB-NWM": "Newham",
+		"GB-NWP": "Newport [Casnewydd GB-CNW]",
+		"GB-NYK": "North Yorkshire",
+		"GB-OLD": "Oldham",
+		"GB-ORK": "Orkney Islands",
+		"GB-OXF": "Oxfordshire",
+		"GB-PEM": "Pembrokeshire [Sir Benfro GB-BNF]",
+		"GB-PKN": "Perth and Kinross",
+		"GB-PLY": "Plymouth",
+		"GB-POR": "Portsmouth",
+		"GB-POW": "Powys",
+		"GB-PTE": "Peterborough",
+		"GB-RCC": "Redcar and Cleveland",
+		"GB-RCH": "Rochdale",
+		"GB-RCT": "Rhondda Cynon Taff [Rhondda CynonTaf]",
+		"GB-RDB": "Redbridge",
+		"GB-RDG": "Reading",
+		"GB-RFW": "Renfrewshire",
+		"GB-RIC": "Richmond upon Thames",
+		"GB-ROT": "Rotherham",
+		"GB-RUT": "Rutland",
+		"GB-SAW": "Sandwell",
+		"GB-SAY": "South Ayrshire",
+		"GB-SCB": "Scottish Borders",
+		"GB-SCT": "Scotland",
+		"GB-SFK": "Suffolk",
+		"GB-SFT": "Sefton",
+		"GB-SGC": "South Gloucestershire",
+		"GB-SHF": "Sheffield",
+		"GB-SHN": "St. Helens",
+		"GB-SHR": "Shropshire",
+		"GB-SKP": "Stockport",
+		"GB-SLF": "Salford",
+		"GB-SLG": "Slough",
+		"GB-SLK": "South Lanarkshire",
+		"GB-SND": "Sunderland",
+		"GB-SOL": "Solihull",
+		"GB-SOM": "Somerset",
+		"GB-SOS": "Southend-on-Sea",
+		"GB-SRY": "Surrey",
+		"GB-STE": "Stoke-on-Trent",
+		"GB-STG": "Stirling",
+		"GB-STH": "Southampton",
+		"GB-STN": "Sutton",
+		"GB-STS": "Staffordshire",
+		"GB-STT": "Stockton-on-Tees",
+		"GB-STY": "South Tyneside",
+		"GB-SWA": "Swansea [Abertawe GB-ATA]",
+		"GB-SWD": "Swindon",
+		"GB-SWK": "Southwark",
+		"GB-TAM": "Tameside",
+		"GB-TFW": "Telford and Wrekin",
+		"GB-THR": "Thurrock",
+		"GB-TOB": "Torbay",
+		"GB-TOF": "Torfaen [Tor-faen]",
+		"GB-TRF": "Trafford",
+		"GB-TWH": "Tower Hamlets",
+		"GB-VGL": "Vale of Glamorgan, The [Bro Morgannwg GB-BMG]",
+		"GB-WAR": "Warwickshire",
+		"GB-WBK": "West Berkshire",
+		"GB-WDU": "West Dunbartonshire",
+		"GB-WFT": "Waltham Forest",
+		"GB-WGN": "Wigan",
+		"GB-WIL": "Wiltshire",
+		"GB-WKF": "Wakefield",
+		"GB-WLL": "Walsall",
+		"GB-WLN": "West Lothian",
+		"GB-WLS": "Wales [Cymru GB-CYM]",
+		"GB-WLV": "Wolverhampton",
+		"GB-WND": "Wandsworth",
+		"GB-WNM": "Windsor and Maidenhead",
+		"GB-WOK": "Wokingham",
+		"GB-WOR": "Worcestershire",
+		"GB-WRL": "Wirral",
+		"GB-WRT": "Warrington",
+		"GB-WRX": "Wrexham [Wrecsam GB-WRC]",
+		"GB-WSM": "Westminster",
+		"GB-WSX": "West Sussex",
+		"GB-YOR": "York",
+		"GB-ZET": "Shetland Islands",
+	},
+	"US": {
+		"US-AK": "Alaska",
+		"US-AL": "Alabama",
+		"US-AR": "Arkansas",
+		"US-AS": "American Samoa",
+		"US-AZ": "Arizona",
+		"US-CA": "California",
+		"US-CO": "Colorado",
+		"US-CT": "Connecticut",
+		"US-DC": "District of Columbia",
+		"US-DE": "Delaware",
+		"US-FL": "Florida",
+		"US-GA": "Georgia",
+		"US-GU": "Guam",
+		"US-HI": "Hawaii",
+		"US-IA": "Iowa",
+		"US-ID": "Idaho",
+		"US-IL": "Illinois",
+		"US-IN": "Indiana",
+		"US-KS": "Kansas",
+		"US-KY": "Kentucky",
+		"US-LA": "Louisiana",
+		"US-MA": "Massachusetts",
+		"US-MD": "Maryland",
+		"US-ME": "Maine",
+		"US-MI": "Michigan",
+		"US-MN": "Minnesota",
+		"US-MO": "Missouri",
+		"US-MP": "Northern Mariana Islands",
+		"US-MS": "Mississippi",
+		"US-MT": "Montana",
+		"US-NC": "North Carolina",
+		"US-ND": "North Dakota",
+		"US-NE": "Nebraska",
+		"US-NH": "New Hampshire",
+		"US-NJ": "New Jersey",
+		"US-NM": "New Mexico",
+		"US-NV": "Nevada",
+		"US-NY": "New York",
+		"US-OH": "Ohio",
+		"US-OK": "Oklahoma",
+		"US-OR": "Oregon",
+		"US-PA": "Pennsylvania",
+		"US-PR": "Puerto Rico",
+		"US-RI": "Rhode Island",
+		"US-SC": "South Carolina",
+		"US-SD": "South Dakota",
+		"US-TN": "Tennessee",
+		"US-TX": "Texas",
+		"US-UM": "United States Minor Outlying Islands",
+		"US-UT": "Utah",
+		"US-VA": "Virginia",
+		"US-VI": "Virgin Islands, U.S.",
+		"US-VT": "Vermont",
+		"US-WA": "Washington",
+		"US-WI": "Wisconsin",
+		"US-WV": "West Virginia",
+		"US-WY": "Wyoming",
+	},
+}
+
+// GetSubdivisions returns the ISO 3166-2 subdivisions of the country with
+// the given alpha-2 code, keyed by full code such as "US-CA", or false if
+// there is no subdivision data for it. Only AU, CA, DE, GB and US are
+// covered. The map is a copy and may be modified.
+func GetSubdivisions(a2 string) (map[string]string, bool) {
+	subs, ok := subdivisions[strings.ToUpper(a2)]
+	if !ok {
+		return nil, false
+	}
+
+	copied := make(map[string]string, len(subs))
+	for code, name := range subs {
+		copied[code] = name
+	}
+
+	return copied, true
+}
+
+// GetBySubdivision returns the name of a subdivision of the given country.
+// The subdivision code may be given in full ("US-CA") or without the country
+// prefix ("CA"), case-insensitively.
+func GetBySubdivision(countryAlpha2, subCode string) (string, bool) {
+	countryAlpha2 = strings.ToUpper(strings.TrimSpace(countryAlpha2))
+	subCode = strings.ToUpper(strings.TrimSpace(subCode))
+
+	if !strings.HasPrefix(subCode, countryAlpha2+"-") {
+		subCode = countryAlpha2 + "-" + subCode
+	}
+
+	name, ok := subdivisions[countryAlpha2][subCode]
+
+	return name, ok
+}
@@ -0,0 +1,178 @@
+package countrycodes
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	// suggest_max_distance is the largest edit distance Suggest accepts.
+	suggest_max_distance = 2
+
+	// suggest_limit is the most candidates Suggest returns.
+	suggest_limit = 5
+)
+
+// Suggest returns up to five entries whose alpha-2 code, alpha-3 code or
+// name is within an edit distance of two of input, ignoring case and
+// diacritics, closest first and then by alpha-2. Officially assigned entries
+// come before reserved ones at the same distance. It returns an empty slice
+// for blank input.
+func Suggest(input string) []CountryCode {
+	key := foldDiacritics(strings.ToLower(strings.TrimSpace(input)))
+	if key == "" {
+		return []CountryCode{}
+	}
+
+	type candidate struct {
+		code     CountryCode
+		distance int
+	}
+	candidates := make([]candidate, 0)
+
+	for _, cc := range All() {
+		best := suggest_max_distance + 1
+		for _, s := range []string{cc.Alpha2, cc.Alpha3, foldDiacritics(cc.Name)} {
+			if d := editDistance(key, strings.ToLower(s)); d < best {
+				best = d
+			}
+		}
+
+		if best <= suggest_max_distance {
+			candidates = append(candidates, candidate{cc, best})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].code.IsOfficiallyAssigned() && !candidates[j].code.IsOfficiallyAssigned()
+	})
+
+	if len(candidates) > suggest_limit {
+		candidates = candidates[:suggest_limit]
+	}
+
+	codes := make([]CountryCode, len(candidates))
+	for i, c := range candidates {
+		codes[i] = c.code
+	}
+
+	return codes
+}
+
+// suggestCode returns the alpha-2 code of the entry whose alpha-2 or alpha-3
+// code is closest to input, ignoring case, or "" if none is within an edit
+// distance of one. The result can always be passed to LookupByAlpha2. Ties go
+// to codes of input's length, so a two-letter input prefers a near alpha-2,
+// then to the code sharing the most letters with input, so that "GRB"
+// suggests "GB" by way of "GBR" rather than "GR" by way of "GRC", then
+// alphabetically.
+func suggestCode(input string) string {
+	key := strings.ToUpper(strings.TrimSpace(input))
+	if key == "" {
+		return ""
+	}
+
+	if code, ok := GetByAlpha3(key); ok {
+		return code.Alpha2
+	}
+
+	type candidate struct {
+		alpha2   string
+		distance int
+		sameLen  bool
+		common   int
+	}
+
+	closer := func(a, b candidate) bool {
+		switch {
+		case a.distance != b.distance:
+			return a.distance < b.distance
+		case a.sameLen != b.sameLen:
+			return a.sameLen
+		case a.common != b.common:
+			return a.common > b.common
+		}
+		return a.alpha2 < b.alpha2
+	}
+
+	best := candidate{distance: 2}
+
+	for _, cc := range All() {
+		if cc.Alpha2 == "" {
+			continue
+		}
+
+		for _, code := range []string{cc.Alpha2, cc.Alpha3} {
+			if len(code) < 2 || len(code) > 3 {
+				continue
+			}
+
+			c := candidate{cc.Alpha2, editDistance(key, code), len(code) == len(key), commonRunes(key, code)}
+			if closer(c, best) {
+				best = c
+			}
+		}
+	}
+
+	return best.alpha2
+}
+
+// commonRunes returns how many runes a and b share, counting repeats.
+func commonRunes(a, b string) int {
+	counts := make(map[rune]int)
+	for _, r := range a {
+		counts[r]++
+	}
+
+	common := 0
+	for _, r := range b {
+		if counts[r] > 0 {
+			counts[r]--
+			common++
+		}
+	}
+
+	return common
+}
+
+// editDistance returns the optimal string alignment distance between a and
+// b: the number of single-rune insertions, deletions, substitutions and
+// transpositions of adjacent runes needed to turn a into b, so "GRB" is one
+// edit from "GBR".
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = d[i-1][j-1] + cost
+			if d[i-1][j]+1 < d[i][j] {
+				d[i][j] = d[i-1][j] + 1
+			}
+			if d[i][j-1]+1 < d[i][j] {
+				d[i][j] = d[i][j-1] + 1
+			}
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] && d[i-2][j-2]+1 < d[i][j] {
+				d[i][j] = d[i-2][j-2] + 1
+			}
+		}
+	}
+
+	return d[len(ra)][len(rb)]
+}
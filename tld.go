@@ -0,0 +1,55 @@
+package countrycodes
+
+import (
+	"strings"
+)
+
+// tld_exceptions holds the ccTLDs that are not simply the lower-cased
+// alpha-2 code of an officially assigned entry. An empty value marks an
+// officially assigned entry with no delegated ccTLD: BL, BQ, EH and MF were
+// never delegated and .um was retired in 2008. Reserved and user-assigned
+// entries have no ccTLD unless listed here, so SU keeps .su while retired
+// domains such as .tp, .yu and .zr are left out.
+var tld_exceptions = map[string]string{
+	"AC": ".ac",
+	"BL": "",
+	"BQ": "",
+	"EH": "",
+	"EU": ".eu",
+	"GB": ".uk",
+	"MF": "",
+	"SU": ".su",
+	"UK": ".uk",
+	"UM": "",
+}
+
+var by_tld map[string]CountryCode
+
+func applyTLDs() {
+	for a2, cc := range by_alpha2 {
+		if tld, ok := tld_exceptions[a2]; ok {
+			cc.TLD = tld
+		} else if cc.Assignment == OFFICIALLY_ASSIGNED {
+			cc.TLD = "." + strings.ToLower(a2)
+		}
+		by_alpha2[a2] = cc
+	}
+}
+
+// GetByTLD returns the entry using the given ccTLD, matched
+// case-insensitively with or without the leading dot. Where an officially
+// assigned entry shares its ccTLD with a reserved one, as GB does with UK,
+// the officially assigned entry is returned, so ".uk" finds GB. ".eu" finds
+// the exceptionally reserved EU entry for the Union, not a member state.
+func GetByTLD(tld string) (CountryCode, bool) {
+	ensureIndexes()
+
+	tld = strings.ToLower(strings.TrimSpace(tld))
+	if !strings.HasPrefix(tld, ".") {
+		tld = "." + tld
+	}
+
+	code := by_tld[tld]
+
+	return code, code.Alpha2 != ""
+}
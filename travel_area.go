@@ -0,0 +1,41 @@
+package countrycodes
+
+import (
+	"strings"
+)
+
+// TravelAreas maps the names of passport-free travel areas to their member
+// alpha-2 codes. "Schengen" is the Schengen Area; "CTA" is the Common Travel
+// Area of the United Kingdom, Ireland and the Crown Dependencies. Callers
+// may amend the sets or add areas, but not while SameTravelArea is running.
+var TravelAreas = map[string][]string{
+	"Schengen": append([]string(nil), schengen_members...),
+	"CTA":      {"GB", "GG", "IE", "IM", "JE"},
+}
+
+// SameTravelArea reports whether travel between the countries a and b, given
+// as alpha-2 codes, stays within the named area of TravelAreas, i.e. both are
+// members. Codes and the area name are matched case-insensitively; an
+// unknown area is never shared.
+func SameTravelArea(a, b string, area string) bool {
+	a, b = strings.ToUpper(strings.TrimSpace(a)), strings.ToUpper(strings.TrimSpace(b))
+
+	for name, members := range TravelAreas {
+		if strings.EqualFold(name, strings.TrimSpace(area)) {
+			return isMember(members, a) && isMember(members, b)
+		}
+	}
+
+	return false
+}
+
+// isMember reports whether a2 is one of members.
+func isMember(members []string, a2 string) bool {
+	for _, m := range members {
+		if m == a2 {
+			return true
+		}
+	}
+
+	return false
+}
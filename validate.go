@@ -0,0 +1,126 @@
+package countrycodes
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrDenied is wrapped by the errors returned from a NewAllowValidator
+// validator for codes on its denylist.
+var ErrDenied = errors.New("countrycodes: country is denied")
+
+// ErrMalformedAlpha2 is wrapped by the errors ValidateAlpha2 returns for
+// input that is not exactly two ASCII letters.
+var ErrMalformedAlpha2 = errors.New("countrycodes: alpha-2 code must be two ASCII letters")
+
+// NewAllowValidator returns a validator that accepts any known alpha-2 or
+// alpha-3 code, case-insensitively, except the denied ones. Denied codes may
+// themselves be given as alpha-2 or alpha-3, so denying "CU" also rejects
+// "cu" and "CUB".
+//
+// Reserved codes cannot be used to slip past the denylist: input is checked
+// under its Canonical entry and every Successor, so denying GB rejects UK and
+// denying RU rejects SU. If a denied value is not a known alpha-2 or alpha-3
+// code the returned validator rejects every input with an error naming it,
+// since silently dropping it would let that country through. Use
+// NewAllowValidatorErr to catch such values up front.
+func NewAllowValidator(denied ...string) func(string) error {
+	validate, err := NewAllowValidatorErr(denied...)
+	if err != nil {
+		return func(string) error {
+			return err
+		}
+	}
+
+	return validate
+}
+
+// NewAllowValidatorErr is like NewAllowValidator but returns an error
+// wrapping ErrNotFound if a denied value is not a known alpha-2 or alpha-3
+// code.
+func NewAllowValidatorErr(denied ...string) (func(string) error, error) {
+	deny := make(map[string]bool, len(denied))
+
+	for _, d := range denied {
+		code, ok := resolveCode(d)
+		if !ok {
+			return nil, fmt.Errorf("%w: denied code %q", ErrNotFound, d)
+		}
+		deny[code.Alpha2] = true
+		deny[code.Canonical().Alpha2] = true
+	}
+
+	return func(s string) error {
+		code, ok := resolveCode(s)
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrNotFound, s)
+		}
+
+		related := append([]CountryCode{code, code.Canonical()}, code.Successors()...)
+		for _, cc := range related {
+			if deny[cc.Alpha2] {
+				return fmt.Errorf("%w: %s", ErrDenied, code.Alpha2)
+			}
+		}
+
+		return nil
+	}, nil
+}
+
+// resolveCode looks s up as an alpha-2 or alpha-3 code, ignoring case and
+// surrounding whitespace.
+func resolveCode(s string) (CountryCode, bool) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+
+	if len(s) == 2 {
+		return GetByAlpha2(s)
+	}
+
+	return GetByAlpha3(s)
+}
+
+// IsValidAlpha2 reports whether a2 is the alpha-2 code of any entry,
+// ignoring case.
+func IsValidAlpha2(a2 string) bool {
+	_, ok := GetByAlpha2(strings.ToUpper(a2))
+
+	return ok
+}
+
+// IsValidAlpha3 reports whether a3 is the alpha-3 code of any entry,
+// ignoring case.
+func IsValidAlpha3(a3 string) bool {
+	_, ok := GetByAlpha3(strings.ToUpper(a3))
+
+	return ok
+}
+
+// IsValidNumeric reports whether n is the numeric code of any entry. The
+// -1 and 0 placeholders used for entries without a known numeric code are
+// not valid.
+func IsValidNumeric(n int) bool {
+	if n <= 0 {
+		return false
+	}
+
+	_, ok := GetByNumeric(n)
+
+	return ok
+}
+
+// ValidateAlpha2 checks s as an alpha-2 code, ignoring case. It returns an
+// error wrapping ErrMalformedAlpha2 if s is not exactly two ASCII letters,
+// one wrapping ErrNotFound if it is well-formed but matches no entry, and
+// nil otherwise.
+func ValidateAlpha2(s string) error {
+	if ClassifyInput(s) != KindAlpha2 || strings.TrimSpace(s) != s {
+		return fmt.Errorf("%w: %q", ErrMalformedAlpha2, s)
+	}
+
+	if !IsValidAlpha2(s) {
+		return fmt.Errorf("%w: alpha-2 %q", ErrNotFound, s)
+	}
+
+	return nil
+}
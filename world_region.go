@@ -0,0 +1,90 @@
+package countrycodes
+
+import (
+	"strconv"
+)
+
+// WorldRegion is one of the five top-level UN M49 regions.
+type WorldRegion int
+
+const (
+	// RegionNone is the zero value, used for entries outside every M49
+	// region, such as Antarctica and the reserved codes.
+	RegionNone WorldRegion = iota
+
+	// RegionAfrica is M49 region 002.
+	RegionAfrica
+
+	// RegionAmericas is M49 region 019, covering North, Central and South
+	// America and the Caribbean.
+	RegionAmericas
+
+	// RegionAsia is M49 region 142.
+	RegionAsia
+
+	// RegionEurope is M49 region 150.
+	RegionEurope
+
+	// RegionOceania is M49 region 009.
+	RegionOceania
+)
+
+var world_region_names = map[WorldRegion]string{
+	RegionNone:     "None",
+	RegionAfrica:   "Africa",
+	RegionAmericas: "Americas",
+	RegionAsia:     "Asia",
+	RegionEurope:   "Europe",
+	RegionOceania:  "Oceania",
+}
+
+// world_region_codes maps the top-level M49 region codes to WorldRegion.
+var world_region_codes = map[int]WorldRegion{
+	2:   RegionAfrica,
+	19:  RegionAmericas,
+	142: RegionAsia,
+	150: RegionEurope,
+	9:   RegionOceania,
+}
+
+// String returns the region's English name, e.g. "Americas", or
+// "WorldRegion(n)" for values outside the defined constants.
+func (r WorldRegion) String() string {
+	if name, ok := world_region_names[r]; ok {
+		return name
+	}
+
+	return "WorldRegion(" + strconv.Itoa(int(r)) + ")"
+}
+
+// applyWorldRegions sets Region from RegionCode, so it must run after
+// applyRegionCodes.
+func applyWorldRegions() {
+	for a2, cc := range by_alpha2 {
+		for r := cc.RegionCode; r != 0; r = region_parents[r] {
+			if region, ok := world_region_codes[r]; ok {
+				cc.Region = region
+				by_alpha2[a2] = cc
+				break
+			}
+		}
+	}
+}
+
+// AllByRegion returns the entries in the given world region, sorted by
+// alpha-2.
+func AllByRegion(r WorldRegion) []CountryCode {
+	codes := make([]CountryCode, 0)
+
+	if r == RegionNone {
+		return codes
+	}
+
+	for _, cc := range All() {
+		if cc.Region == r {
+			codes = append(codes, cc)
+		}
+	}
+
+	return codes
+}